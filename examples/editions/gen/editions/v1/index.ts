@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-typescript-http. DO NOT EDIT.
+/* eslint-disable camelcase */
+
+export type Format =
+  | "FORMAT_PAPERBACK"
+  | "FORMAT_HARDCOVER";
+
+/**
+ * The names of Format by number.
+ */
+export const FormatByNumber: Readonly<Record<number, Format>> = Object.freeze({
+  1: "FORMAT_PAPERBACK",
+  2: "FORMAT_HARDCOVER",
+});
+
+/**
+ * Converts a numeric value of Format to its name.
+ */
+export function normalizeFormat(value: Format | number): Format {
+  return typeof value === "number" ? FormatByNumber[value] : value;
+}
+
+export type Genre =
+  | "GENRE_UNSPECIFIED"
+  | "GENRE_FICTION"
+  | "GENRE_SCIENCE";
+
+/**
+ * The names of Genre by number.
+ */
+export const GenreByNumber: Readonly<Record<number, Genre>> = Object.freeze({
+  0: "GENRE_UNSPECIFIED",
+  1: "GENRE_FICTION",
+  2: "GENRE_SCIENCE",
+});
+
+/**
+ * Converts a numeric value of Genre to its name.
+ * Numbers that are not known to this version of the enum are returned unchanged.
+ */
+export function normalizeGenre(value: Genre | number): Genre | number {
+  return typeof value === "number" ? GenreByNumber[value] ?? value : value;
+}
+
+type RequestType = {
+  path: string;
+  method: string;
+  body: string | null;
+};
+
+type RequestHandler = (request: RequestType, meta: { service: string, method: string }) => Promise<unknown>;
+
+export type Book = {
+  /**
+   * Behaviors: IDENTIFIER
+   */
+  name?: string;
+  title?: string;
+  pageCount: number;
+  genre?: Genre;
+  format?: Format;
+  authors: string[];
+};
+
+export type Book__Response = {
+  /**
+   * Behaviors: IDENTIFIER
+   */
+  name?: string;
+  title?: string;
+  pageCount: number;
+  genre?: Genre;
+  format?: Format;
+  authors: string[];
+};
+
+/**
+ * Converts numeric enum values in a response to their names, recursively.
+ * Numbers that are not known to the generated enums are kept unchanged.
+ */
+export function normalizeBook__Response(message: any): Book__Response { // eslint-disable-line @typescript-eslint/no-explicit-any
+  const result: Record<string, unknown> = { ...message };
+  if (message.genre !== undefined && message.genre !== null) {
+    result.genre = normalizeGenre(message.genre);
+  }
+  if (message.format !== undefined && message.format !== null) {
+    result.format = normalizeFormat(message.format);
+  }
+  return result as Book__Response;
+}
+
+/**
+ * Converts numeric enum values in a response to their names, recursively.
+ * Numbers that are not known to the generated enums are kept unchanged.
+ */
+export function normalizeBook(message: any): Book { // eslint-disable-line @typescript-eslint/no-explicit-any
+  const result: Record<string, unknown> = { ...message };
+  if (message.genre !== undefined && message.genre !== null) {
+    result.genre = normalizeGenre(message.genre);
+  }
+  if (message.format !== undefined && message.format !== null) {
+    result.format = normalizeFormat(message.format);
+  }
+  return result as Book;
+}
+
+export type GetBookRequest = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  name?: string;
+};
+
+export type GetBookRequest__Request = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+export type ListBooksRequest = {
+  pageSize?: number;
+  pageToken: string;
+  genre?: Genre;
+};
+
+export type ListBooksRequest__Request = {
+  pageSize?: number;
+  pageToken: string;
+  genre?: Genre;
+};
+
+export type ListBooksResponse = {
+  books: Book[];
+  nextPageToken: string;
+};
+
+export type ListBooksResponse__Response = {
+  books: Book[];
+  nextPageToken: string;
+};
+
+/**
+ * Converts numeric enum values in a response to their names, recursively.
+ * Numbers that are not known to the generated enums are kept unchanged.
+ */
+export function normalizeListBooksResponse__Response(message: any): ListBooksResponse__Response { // eslint-disable-line @typescript-eslint/no-explicit-any
+  const result: Record<string, unknown> = { ...message };
+  if (message.books !== undefined && message.books !== null) {
+    result.books = message.books.map((value) => normalizeBook(value));
+  }
+  return result as ListBooksResponse__Response;
+}
+
+export interface LibraryService {
+  GetBook(request: GetBookRequest__Request): Promise<Book__Response>;
+  ListBooks(request: ListBooksRequest__Request): Promise<ListBooksResponse__Response>;
+}
+
+export function createLibraryServiceClient(
+  handler: RequestHandler
+): LibraryService {
+  return {
+    GetBook(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
+      if (!request.name) {
+        throw new Error("missing required field request.name");
+      }
+      if (!/^books\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"books/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
+      const body = null;
+      const queryParams: string[] = [];
+      let uri = path;
+      if (queryParams.length > 0) {
+        uri += `?${queryParams.join("&")}`
+      }
+      return handler({
+        path: uri,
+        method: "GET",
+        body,
+      }, {
+        service: "LibraryService",
+        method: "GetBook",
+      }).then((response) => normalizeBook__Response(response));
+    },
+    ListBooks(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
+      const path = `v1/books`; // eslint-disable-line quotes
+      const body = null;
+      const queryParams: string[] = [];
+      if (request.pageSize !== undefined && request.pageSize !== null) {
+        queryParams.push(`pageSize=${encodeURIComponent(String(request.pageSize))}`)
+      }
+      if (request.pageToken !== "") {
+        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken)}`)
+      }
+      if (request.genre !== undefined && request.genre !== null) {
+        queryParams.push(`genre=${encodeURIComponent(request.genre)}`)
+      }
+      let uri = path;
+      if (queryParams.length > 0) {
+        uri += `?${queryParams.join("&")}`
+      }
+      return handler({
+        path: uri,
+        method: "GET",
+        body,
+      }, {
+        service: "LibraryService",
+        method: "ListBooks",
+      }).then((response) => normalizeListBooksResponse__Response(response));
+    },
+  };
+}
+
+/**
+ * Percent-encodes all characters except [-_.~0-9a-zA-Z].
+ */
+function encodePathSegment(value: string): string {
+  return encodeURIComponent(value).replace(/[!'()*]/g, (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`);
+}
+
+/**
+ * Percent-encodes all characters except [-_.~/0-9a-zA-Z].
+ */
+function encodePathSegments(value: string): string {
+  return value.split("/").map(encodePathSegment).join("/");
+}
+
+// @@protoc_insertion_point(typescript-http-eof)
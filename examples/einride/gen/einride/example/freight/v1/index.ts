@@ -1,13 +1,7 @@
 // Code generated by protoc-gen-typescript-http. DO NOT EDIT.
 /* eslint-disable camelcase */
-// @ts-nocheck
 
-/**
- * Encoded using RFC 3339, where generated output will always be Z-normalized
- * and uses 0, 3, 6 or 9 fractional digits.
- * Offsets other than "Z" are also accepted.
- */
-type wellKnownTimestamp = string;
+import type { LatLng } from "../../../../google/type";
 
 /**
  * In JSON, a field mask is encoded as a single string where paths are
@@ -39,6 +33,13 @@ type wellKnownTimestamp = string;
  */
 type wellKnownFieldMask = string;
 
+/**
+ * Encoded using RFC 3339, where generated output will always be Z-normalized
+ * and uses 0, 3, 6 or 9 fractional digits.
+ * Offsets other than "Z" are also accepted.
+ */
+type wellKnownTimestamp = string;
+
 type RequestType = {
   path: string;
   method: string;
@@ -48,52 +49,84 @@ type RequestType = {
 type RequestHandler = (request: RequestType, meta: { service: string, method: string }) => Promise<unknown>;
 
 /**
- * An object that represents a latitude/longitude pair. This is expressed as a
- * pair of doubles to represent degrees latitude and degrees longitude. Unless
- * specified otherwise, this must conform to the
- * <a href="http://www.unoosa.org/pdf/icg/2012/template/WGS_84.pdf">WGS84
- * standard</a>. Values must be within normalized ranges.
+ * Request message for FreightService.CreateShipment.
  */
-export type googletype_LatLng = {
+export type CreateShipmentRequest = {
   /**
-   * The latitude in degrees. It must be in the range [-90.0, +90.0].
+   * The resource name of the parent shipper for which this shipment will be created.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
    */
-  latitude: number;
+  parent: string;
   /**
-   * The longitude in degrees. It must be in the range [-180.0, +180.0].
+   * The shipment to create.
+   * 
+   * Behaviors: REQUIRED
    */
-  longitude: number;
+  shipment?: Shipment;
 };
 
 /**
- * Request message for FreightService.ListShippers.
+ * Request message for FreightService.CreateShipment.
  */
-export type ListShippersRequest__Request = {
+export type CreateShipmentRequest__Request = {
   /**
-   * Requested page size. Server may return fewer shippers than requested.
-   * If unspecified, server will pick an appropriate default.
+   * The resource name of the parent shipper for which this shipment will be created.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
    */
-  pageSize: number;
+  parent: string;
   /**
-   * A token identifying a page of results the server should return.
-   * Typically, this is the value of
-   * [ListShippersResponse.next_page_token][einride.example.freight.v1.ListShippersResponse.next_page_token]
-   * returned from the previous call to `ListShippers` method.
+   * The shipment to create.
+   * 
+   * Behaviors: REQUIRED
    */
-  pageToken: string;
+  shipment: Shipment;
 };
 
 /**
- * Request message for FreightService.DeleteShipper.
+ * Request message for FreightService.CreateShipper.
  */
-export type DeleteShipperRequest__Request = {
+export type CreateShipperRequest = {
   /**
-   * The resource name of the shipper to delete.
+   * The shipper to create.
+   * 
+   * Behaviors: REQUIRED
+   */
+  shipper?: Shipper;
+};
+
+/**
+ * Request message for FreightService.CreateShipper.
+ */
+export type CreateShipperRequest__Request = {
+  /**
+   * The shipper to create.
+   * 
+   * Behaviors: REQUIRED
+   */
+  shipper: Shipper;
+};
+
+/**
+ * Request message for FreightService.CreateSite.
+ */
+export type CreateSiteRequest = {
+  /**
+   * The resource name of the parent shipper for which this site will be created.
    * Format: shippers/{shipper}
    * 
    * Behaviors: REQUIRED
    */
-  name: string;
+  parent: string;
+  /**
+   * The site to create.
+   * 
+   * Behaviors: REQUIRED
+   */
+  site?: Site;
 };
 
 /**
@@ -116,51 +149,496 @@ export type CreateSiteRequest__Request = {
 };
 
 /**
- * Response message for FreightService.ListShipments.
+ * Request message for FreightService.DeleteShipment.
  */
-export type ListShipmentsResponse__Response = {
+export type DeleteShipmentRequest = {
   /**
-   * The list of shipments.
+   * The resource name of the shipment to delete.
+   * Format: shippers/{shipper}/shipments/{shipment}
+   * 
+   * Behaviors: REQUIRED
    */
-  shipments: Shipment[];
+  name: string;
+};
+
+/**
+ * Request message for FreightService.DeleteShipment.
+ */
+export type DeleteShipmentRequest__Request = {
   /**
-   * A token to retrieve next page of results.  Pass this value in the
-   * [ListShipmentsRequest.page_token][einride.example.freight.v1.ListShipmentsRequest.page_token]
-   * field in the subsequent call to `ListShipments` method to retrieve the next
-   * page of results.
+   * The resource name of the shipment to delete.
+   * Format: shippers/{shipper}/shipments/{shipment}
+   * 
+   * Behaviors: REQUIRED
    */
-  nextPageToken: string;
+  name: string;
 };
 
 /**
- * Request message for FreightService.UpdateShipment.
+ * Request message for FreightService.DeleteShipper.
  */
-export type UpdateShipmentRequest__Request = {
+export type DeleteShipperRequest = {
   /**
-   * The shipment to update with. The name must match or be empty.
-   * The shipment's `name` field is used to identify the shipment to be updated.
+   * The resource name of the shipper to delete.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.DeleteShipper.
+ */
+export type DeleteShipperRequest__Request = {
+  /**
+   * The resource name of the shipper to delete.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.DeleteSite.
+ */
+export type DeleteSiteRequest = {
+  /**
+   * The resource name of the site to delete.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.DeleteSite.
+ */
+export type DeleteSiteRequest__Request = {
+  /**
+   * The resource name of the site to delete.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.GetShipment.
+ */
+export type GetShipmentRequest = {
+  /**
+   * The resource name of the shipment to retrieve.
    * Format: shippers/{shipper}/shipments/{shipment}
    * 
    * Behaviors: REQUIRED
    */
-  shipment: Shipment;
+  name: string;
+};
+
+/**
+ * Request message for FreightService.GetShipment.
+ */
+export type GetShipmentRequest__Request = {
   /**
-   * The list of fields to be updated.
+   * The resource name of the shipment to retrieve.
+   * Format: shippers/{shipper}/shipments/{shipment}
+   * 
+   * Behaviors: REQUIRED
    */
-  updateMask: wellKnownFieldMask;
+  name: string;
 };
 
 /**
- * Request message for FreightService.DeleteShipment.
+ * Request message for FreightService.GetShipper.
  */
-export type DeleteShipmentRequest__Request = {
+export type GetShipperRequest = {
+  /**
+   * The resource name of the shipper to retrieve.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.GetShipper.
+ */
+export type GetShipperRequest__Request = {
+  /**
+   * The resource name of the shipper to retrieve.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.GetSite.
+ */
+export type GetSiteRequest = {
+  /**
+   * The resource name of the site to retrieve.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * Request message for FreightService.GetSite.
+ */
+export type GetSiteRequest__Request = {
+  /**
+   * The resource name of the site to retrieve.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  name: string;
+};
+
+/**
+ * A shipment line item.
+ */
+export type LineItem = {
+  /**
+   * The title of the line item.
+   */
+  title: string;
+  /**
+   * The quantity of the line item.
+   */
+  quantity: number;
+  /**
+   * The weight of the line item in kilograms.
+   */
+  weightKg: number;
+  /**
+   * The volume of the line item in cubic meters.
+   */
+  volumeM3: number;
+};
+
+/**
+ * Request message for FreightService.ListShipments.
+ */
+export type ListShipmentsRequest = {
+  /**
+   * The resource name of the parent, which owns this collection of shipments.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * Requested page size. Server may return fewer shipments than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListShipmentsResponse.next_page_token][einride.example.freight.v1.ListShipmentsResponse.next_page_token]
+   * returned from the previous call to `ListShipments` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Request message for FreightService.ListShipments.
+ */
+export type ListShipmentsRequest__Request = {
+  /**
+   * The resource name of the parent, which owns this collection of shipments.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * Requested page size. Server may return fewer shipments than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListShipmentsResponse.next_page_token][einride.example.freight.v1.ListShipmentsResponse.next_page_token]
+   * returned from the previous call to `ListShipments` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListShipments.
+ */
+export type ListShipmentsResponse = {
+  /**
+   * The list of shipments.
+   */
+  shipments: Shipment[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListShipmentsRequest.page_token][einride.example.freight.v1.ListShipmentsRequest.page_token]
+   * field in the subsequent call to `ListShipments` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListShipments.
+ */
+export type ListShipmentsResponse__Response = {
+  /**
+   * The list of shipments.
+   */
+  shipments: Shipment[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListShipmentsRequest.page_token][einride.example.freight.v1.ListShipmentsRequest.page_token]
+   * field in the subsequent call to `ListShipments` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Request message for FreightService.ListShippers.
+ */
+export type ListShippersRequest = {
+  /**
+   * Requested page size. Server may return fewer shippers than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListShippersResponse.next_page_token][einride.example.freight.v1.ListShippersResponse.next_page_token]
+   * returned from the previous call to `ListShippers` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Request message for FreightService.ListShippers.
+ */
+export type ListShippersRequest__Request = {
+  /**
+   * Requested page size. Server may return fewer shippers than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListShippersResponse.next_page_token][einride.example.freight.v1.ListShippersResponse.next_page_token]
+   * returned from the previous call to `ListShippers` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListShippers.
+ */
+export type ListShippersResponse = {
+  /**
+   * The list of shippers.
+   */
+  shippers: Shipper[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListShippersRequest.page_token][einride.example.freight.v1.ListShippersRequest.page_token]
+   * field in the subsequent call to `ListShippers` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListShippers.
+ */
+export type ListShippersResponse__Response = {
+  /**
+   * The list of shippers.
+   */
+  shippers: Shipper[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListShippersRequest.page_token][einride.example.freight.v1.ListShippersRequest.page_token]
+   * field in the subsequent call to `ListShippers` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Request message for FreightService.ListSites.
+ */
+export type ListSitesRequest = {
+  /**
+   * The resource name of the parent, which owns this collection of sites.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * Requested page size. Server may return fewer sites than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListSitesResponse.next_page_token][einride.example.freight.v1.ListSitesResponse.next_page_token]
+   * returned from the previous call to `ListSites` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Request message for FreightService.ListSites.
+ */
+export type ListSitesRequest__Request = {
+  /**
+   * The resource name of the parent, which owns this collection of sites.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * Requested page size. Server may return fewer sites than requested.
+   * If unspecified, server will pick an appropriate default.
+   */
+  pageSize: number;
+  /**
+   * A token identifying a page of results the server should return.
+   * Typically, this is the value of
+   * [ListSitesResponse.next_page_token][einride.example.freight.v1.ListSitesResponse.next_page_token]
+   * returned from the previous call to `ListSites` method.
+   */
+  pageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListSites.
+ */
+export type ListSitesResponse = {
+  /**
+   * The list of sites.
+   */
+  sites: Site[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListSitesRequest.page_token][einride.example.freight.v1.ListSitesRequest.page_token]
+   * field in the subsequent call to `ListSites` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Response message for FreightService.ListSites.
+ */
+export type ListSitesResponse__Response = {
+  /**
+   * The list of sites.
+   */
+  sites: Site[];
+  /**
+   * A token to retrieve next page of results.  Pass this value in the
+   * [ListSitesRequest.page_token][einride.example.freight.v1.ListSitesRequest.page_token]
+   * field in the subsequent call to `ListSites` method to retrieve the next
+   * page of results.
+   */
+  nextPageToken: string;
+};
+
+/**
+ * A shipment represents transportation of goods between an origin
+ * [site][einride.example.freight.v1.Site] and a destination
+ * [site][einride.example.freight.v1.Site].
+ */
+export type Shipment = {
+  /**
+   * The resource name of the shipment.
+   */
+  name: string;
+  /**
+   * The creation timestamp of the shipment.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  createTime?: wellKnownTimestamp;
+  /**
+   * The last update timestamp of the shipment.
+   * Updated when create/update/delete operation is shipment.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  updateTime?: wellKnownTimestamp;
+  /**
+   * The deletion timestamp of the shipment.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  deleteTime?: wellKnownTimestamp;
+  /**
+   * The resource name of the origin site of the shipment.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  originSite: string;
+  /**
+   * The resource name of the destination site of the shipment.
+   * Format: shippers/{shipper}/sites/{site}
+   * 
+   * Behaviors: REQUIRED
+   */
+  destinationSite: string;
+  /**
+   * The earliest pickup time of the shipment at the origin site.
+   * 
+   * Behaviors: REQUIRED
+   */
+  pickupEarliestTime?: wellKnownTimestamp;
+  /**
+   * The latest pickup time of the shipment at the origin site.
+   * 
+   * Behaviors: REQUIRED
+   */
+  pickupLatestTime?: wellKnownTimestamp;
+  /**
+   * The earliest delivery time of the shipment at the destination site.
+   * 
+   * Behaviors: REQUIRED
+   */
+  deliveryEarliestTime?: wellKnownTimestamp;
   /**
-   * The resource name of the shipment to delete.
-   * Format: shippers/{shipper}/shipments/{shipment}
+   * The latest delivery time of the shipment at the destination site.
    * 
    * Behaviors: REQUIRED
    */
-  name: string;
+  deliveryLatestTime?: wellKnownTimestamp;
+  /**
+   * The line items of the shipment.
+   */
+  lineItems: LineItem[];
+  /**
+   * Annotations of the shipment.
+   */
+  annotations: { [key: string]: string };
 };
 
 /**
@@ -178,20 +656,20 @@ export type Shipment__Response = {
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  createTime: wellKnownTimestamp;
+  createTime?: wellKnownTimestamp;
   /**
    * The last update timestamp of the shipment.
    * Updated when create/update/delete operation is shipment.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  updateTime: wellKnownTimestamp;
+  updateTime?: wellKnownTimestamp;
   /**
    * The deletion timestamp of the shipment.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  deleteTime: wellKnownTimestamp;
+  deleteTime?: wellKnownTimestamp;
   /**
    * The resource name of the origin site of the shipment.
    * Format: shippers/{shipper}/sites/{site}
@@ -211,25 +689,25 @@ export type Shipment__Response = {
    * 
    * Behaviors: REQUIRED
    */
-  pickupEarliestTime: wellKnownTimestamp;
+  pickupEarliestTime?: wellKnownTimestamp;
   /**
    * The latest pickup time of the shipment at the origin site.
    * 
    * Behaviors: REQUIRED
    */
-  pickupLatestTime: wellKnownTimestamp;
+  pickupLatestTime?: wellKnownTimestamp;
   /**
    * The earliest delivery time of the shipment at the destination site.
    * 
    * Behaviors: REQUIRED
    */
-  deliveryEarliestTime: wellKnownTimestamp;
+  deliveryEarliestTime?: wellKnownTimestamp;
   /**
    * The latest delivery time of the shipment at the destination site.
    * 
    * Behaviors: REQUIRED
    */
-  deliveryLatestTime: wellKnownTimestamp;
+  deliveryLatestTime?: wellKnownTimestamp;
   /**
    * The line items of the shipment.
    */
@@ -241,106 +719,38 @@ export type Shipment__Response = {
 };
 
 /**
- * A shipment line item.
- */
-export type LineItem = {
-  /**
-   * The title of the line item.
-   */
-  title: string;
-  /**
-   * The quantity of the line item.
-   */
-  quantity: number;
-  /**
-   * The weight of the line item in kilograms.
-   */
-  weightKg: number;
-  /**
-   * The volume of the line item in cubic meters.
-   */
-  volumeM3: number;
-};
-
-/**
- * Request message for FreightService.GetShipper.
+ * A shipper is a supplier or owner of goods to be transported.
  */
-export type GetShipperRequest__Request = {
+export type Shipper = {
   /**
-   * The resource name of the shipper to retrieve.
-   * Format: shippers/{shipper}
-   * 
-   * Behaviors: REQUIRED
+   * The resource name of the shipper.
    */
   name: string;
-};
-
-/**
- * Request message for FreightService.GetSite.
- */
-export type GetSiteRequest__Request = {
   /**
-   * The resource name of the site to retrieve.
-   * Format: shippers/{shipper}/sites/{site}
+   * The creation timestamp of the shipper.
    * 
-   * Behaviors: REQUIRED
-   */
-  name: string;
-};
-
-/**
- * Response message for FreightService.ListSites.
- */
-export type ListSitesResponse__Response = {
-  /**
-   * The list of sites.
+   * Behaviors: OUTPUT_ONLY
    */
-  sites: Site[];
+  createTime?: wellKnownTimestamp;
   /**
-   * A token to retrieve next page of results.  Pass this value in the
-   * [ListSitesRequest.page_token][einride.example.freight.v1.ListSitesRequest.page_token]
-   * field in the subsequent call to `ListSites` method to retrieve the next
-   * page of results.
+   * The last update timestamp of the shipper.
+   * Updated when create/update/delete operation is performed.
+   * 
+   * Behaviors: OUTPUT_ONLY
    */
-  nextPageToken: string;
-};
-
-/**
- * Request message for FreightService.DeleteSite.
- */
-export type DeleteSiteRequest__Request = {
+  updateTime?: wellKnownTimestamp;
   /**
-   * The resource name of the site to delete.
-   * Format: shippers/{shipper}/sites/{site}
+   * The deletion timestamp of the shipper.
    * 
-   * Behaviors: REQUIRED
+   * Behaviors: OUTPUT_ONLY
    */
-  name: string;
-};
-
-/**
- * Request message for FreightService.ListShipments.
- */
-export type ListShipmentsRequest__Request = {
+  deleteTime?: wellKnownTimestamp;
   /**
-   * The resource name of the parent, which owns this collection of shipments.
-   * Format: shippers/{shipper}
+   * The display name of the shipper.
    * 
    * Behaviors: REQUIRED
    */
-  parent: string;
-  /**
-   * Requested page size. Server may return fewer shipments than requested.
-   * If unspecified, server will pick an appropriate default.
-   */
-  pageSize: number;
-  /**
-   * A token identifying a page of results the server should return.
-   * Typically, this is the value of
-   * [ListShipmentsResponse.next_page_token][einride.example.freight.v1.ListShipmentsResponse.next_page_token]
-   * returned from the previous call to `ListShipments` method.
-   */
-  pageToken: string;
+  displayName: string;
 };
 
 /**
@@ -356,20 +766,20 @@ export type Shipper__Response = {
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  createTime: wellKnownTimestamp;
+  createTime?: wellKnownTimestamp;
   /**
    * The last update timestamp of the shipper.
    * Updated when create/update/delete operation is performed.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  updateTime: wellKnownTimestamp;
+  updateTime?: wellKnownTimestamp;
   /**
    * The deletion timestamp of the shipper.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  deleteTime: wellKnownTimestamp;
+  deleteTime?: wellKnownTimestamp;
   /**
    * The display name of the shipper.
    * 
@@ -379,32 +789,43 @@ export type Shipper__Response = {
 };
 
 /**
- * Response message for FreightService.ListShippers.
+ * A site is a node in a [shipper][einride.example.freight.v1.Shipper]'s
+ * transport network.
  */
-export type ListShippersResponse__Response = {
+export type Site = {
   /**
-   * The list of shippers.
+   * The resource name of the site.
    */
-  shippers: Shipper[];
+  name: string;
   /**
-   * A token to retrieve next page of results.  Pass this value in the
-   * [ListShippersRequest.page_token][einride.example.freight.v1.ListShippersRequest.page_token]
-   * field in the subsequent call to `ListShippers` method to retrieve the next
-   * page of results.
+   * The creation timestamp of the site.
+   * 
+   * Behaviors: OUTPUT_ONLY
    */
-  nextPageToken: string;
-};
-
-/**
- * Request message for FreightService.CreateShipper.
- */
-export type CreateShipperRequest__Request = {
+  createTime?: wellKnownTimestamp;
   /**
-   * The shipper to create.
+   * The last update timestamp of the site.
+   * Updated when create/update/delete operation is performed.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  updateTime?: wellKnownTimestamp;
+  /**
+   * The deletion timestamp of the site.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  deleteTime?: wellKnownTimestamp;
+  /**
+   * The display name of the site.
    * 
    * Behaviors: REQUIRED
    */
-  shipper: Shipper;
+  displayName: string;
+  /**
+   * The geographic location of the site.
+   */
+  latLng?: LatLng;
 };
 
 /**
@@ -421,20 +842,20 @@ export type Site__Response = {
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  createTime: wellKnownTimestamp;
+  createTime?: wellKnownTimestamp;
   /**
    * The last update timestamp of the site.
    * Updated when create/update/delete operation is performed.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  updateTime: wellKnownTimestamp;
+  updateTime?: wellKnownTimestamp;
   /**
    * The deletion timestamp of the site.
    * 
    * Behaviors: OUTPUT_ONLY
    */
-  deleteTime: wellKnownTimestamp;
+  deleteTime?: wellKnownTimestamp;
   /**
    * The display name of the site.
    * 
@@ -444,64 +865,75 @@ export type Site__Response = {
   /**
    * The geographic location of the site.
    */
-  latLng: googletype_LatLng;
+  latLng?: LatLng;
 };
 
 /**
- * Request message for FreightService.UpdateShipper.
+ * Request message for FreightService.UpdateShipment.
  */
-export type UpdateShipperRequest__Request = {
+export type UpdateShipmentRequest = {
   /**
-   * The shipper to update with. The name must match or be empty.
-   * The shipper's `name` field is used to identify the shipper to be updated.
-   * Format: shippers/{shipper}
+   * The shipment to update with. The name must match or be empty.
+   * The shipment's `name` field is used to identify the shipment to be updated.
+   * Format: shippers/{shipper}/shipments/{shipment}
    * 
    * Behaviors: REQUIRED
    */
-  shipper: Shipper;
+  shipment?: Shipment;
   /**
    * The list of fields to be updated.
    */
-  updateMask: wellKnownFieldMask;
+  updateMask?: wellKnownFieldMask;
 };
 
 /**
- * Request message for FreightService.ListSites.
+ * Request message for FreightService.UpdateShipment.
  */
-export type ListSitesRequest__Request = {
+export type UpdateShipmentRequest__Request = {
   /**
-   * The resource name of the parent, which owns this collection of sites.
-   * Format: shippers/{shipper}
+   * The shipment to update with. The name must match or be empty.
+   * The shipment's `name` field is used to identify the shipment to be updated.
+   * Format: shippers/{shipper}/shipments/{shipment}
    * 
    * Behaviors: REQUIRED
    */
-  parent: string;
+  shipment: Shipment;
   /**
-   * Requested page size. Server may return fewer sites than requested.
-   * If unspecified, server will pick an appropriate default.
+   * The list of fields to be updated.
    */
-  pageSize: number;
+  updateMask: wellKnownFieldMask;
+};
+
+/**
+ * Request message for FreightService.UpdateShipper.
+ */
+export type UpdateShipperRequest = {
   /**
-   * A token identifying a page of results the server should return.
-   * Typically, this is the value of
-   * [ListSitesResponse.next_page_token][einride.example.freight.v1.ListSitesResponse.next_page_token]
-   * returned from the previous call to `ListSites` method.
+   * The shipper to update with. The name must match or be empty.
+   * The shipper's `name` field is used to identify the shipper to be updated.
+   * Format: shippers/{shipper}
+   * 
+   * Behaviors: REQUIRED
    */
-  pageToken: string;
+  shipper?: Shipper;
+  /**
+   * The list of fields to be updated.
+   */
+  updateMask?: wellKnownFieldMask;
 };
 
 /**
- * Request message for FreightService.UpdateSite.
+ * Request message for FreightService.UpdateShipper.
  */
-export type UpdateSiteRequest__Request = {
+export type UpdateShipperRequest__Request = {
   /**
-   * The site to update with. The name must match or be empty.
-   * The site's `name` field is used to identify the site to be updated.
-   * Format: shippers/{shipper}/sites/{site}
+   * The shipper to update with. The name must match or be empty.
+   * The shipper's `name` field is used to identify the shipper to be updated.
+   * Format: shippers/{shipper}
    * 
    * Behaviors: REQUIRED
    */
-  site: Site;
+  shipper: Shipper;
   /**
    * The list of fields to be updated.
    */
@@ -509,35 +941,39 @@ export type UpdateSiteRequest__Request = {
 };
 
 /**
- * Request message for FreightService.GetShipment.
+ * Request message for FreightService.UpdateSite.
  */
-export type GetShipmentRequest__Request = {
+export type UpdateSiteRequest = {
   /**
-   * The resource name of the shipment to retrieve.
-   * Format: shippers/{shipper}/shipments/{shipment}
+   * The site to update with. The name must match or be empty.
+   * The site's `name` field is used to identify the site to be updated.
+   * Format: shippers/{shipper}/sites/{site}
    * 
    * Behaviors: REQUIRED
    */
-  name: string;
+  site?: Site;
+  /**
+   * The list of fields to be updated.
+   */
+  updateMask?: wellKnownFieldMask;
 };
 
 /**
- * Request message for FreightService.CreateShipment.
+ * Request message for FreightService.UpdateSite.
  */
-export type CreateShipmentRequest__Request = {
+export type UpdateSiteRequest__Request = {
   /**
-   * The resource name of the parent shipper for which this shipment will be created.
-   * Format: shippers/{shipper}
+   * The site to update with. The name must match or be empty.
+   * The site's `name` field is used to identify the site to be updated.
+   * Format: shippers/{shipper}/sites/{site}
    * 
    * Behaviors: REQUIRED
    */
-  parent: string;
+  site: Site;
   /**
-   * The shipment to create.
-   * 
-   * Behaviors: REQUIRED
+   * The list of fields to be updated.
    */
-  shipment: Shipment;
+  updateMask: wellKnownFieldMask;
 };
 
 /**
@@ -639,7 +1075,10 @@ export function createFreightServiceClient(
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -653,17 +1092,17 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "GetShipper",
-      }) as Promise<Shipper>;
+      }) as Promise<Shipper__Response>;
     },
     ListShippers(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       const path = `v1/shippers`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
-      if (request.pageSize) {
-        queryParams.push(`pageSize=${encodeURIComponent(request.pageSize.toString())}`)
+      if (request.pageSize !== 0) {
+        queryParams.push(`pageSize=${encodeURIComponent(String(request.pageSize))}`)
       }
-      if (request.pageToken) {
-        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken.toString())}`)
+      if (request.pageToken !== "") {
+        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -676,7 +1115,7 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "ListShippers",
-      }) as Promise<ListShippersResponse>;
+      }) as Promise<ListShippersResponse__Response>;
     },
     CreateShipper(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       const path = `v1/shippers`; // eslint-disable-line quotes
@@ -693,17 +1132,20 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "CreateShipper",
-      }) as Promise<Shipper>;
+      }) as Promise<Shipper__Response>;
     },
     UpdateShipper(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.shipper?.name) {
         throw new Error("missing required field request.shipper.name");
       }
-      const path = `v1/${request.shipper.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.shipper.name)) {
+        throw new Error("field request.shipper.name must match \"shippers/*\", got: " + JSON.stringify(request.shipper.name));
+      }
+      const path = `v1/${encodePathSegments(request.shipper.name)}`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.shipper ?? {});
       const queryParams: string[] = [];
-      if (request.updateMask) {
-        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask.toString())}`)
+      if (request.updateMask !== undefined && request.updateMask !== null) {
+        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -716,13 +1158,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "UpdateShipper",
-      }) as Promise<Shipper>;
+      }) as Promise<Shipper__Response>;
     },
     DeleteShipper(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -736,13 +1181,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "DeleteShipper",
-      }) as Promise<Shipper>;
+      }) as Promise<Shipper__Response>;
     },
     GetSite(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/sites\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*/sites/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -756,20 +1204,23 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "GetSite",
-      }) as Promise<Site>;
+      }) as Promise<Site__Response>;
     },
     ListSites(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.parent) {
         throw new Error("missing required field request.parent");
       }
-      const path = `v1/${request.parent}/sites`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.parent)) {
+        throw new Error("field request.parent must match \"shippers/*\", got: " + JSON.stringify(request.parent));
+      }
+      const path = `v1/${encodePathSegments(request.parent)}/sites`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
-      if (request.pageSize) {
-        queryParams.push(`pageSize=${encodeURIComponent(request.pageSize.toString())}`)
+      if (request.pageSize !== 0) {
+        queryParams.push(`pageSize=${encodeURIComponent(String(request.pageSize))}`)
       }
-      if (request.pageToken) {
-        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken.toString())}`)
+      if (request.pageToken !== "") {
+        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -782,13 +1233,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "ListSites",
-      }) as Promise<ListSitesResponse>;
+      }) as Promise<ListSitesResponse__Response>;
     },
     CreateSite(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.parent) {
         throw new Error("missing required field request.parent");
       }
-      const path = `v1/${request.parent}/sites`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.parent)) {
+        throw new Error("field request.parent must match \"shippers/*\", got: " + JSON.stringify(request.parent));
+      }
+      const path = `v1/${encodePathSegments(request.parent)}/sites`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.site ?? {});
       const queryParams: string[] = [];
       let uri = path;
@@ -802,17 +1256,20 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "CreateSite",
-      }) as Promise<Site>;
+      }) as Promise<Site__Response>;
     },
     UpdateSite(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.site?.name) {
         throw new Error("missing required field request.site.name");
       }
-      const path = `v1/${request.site.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/sites\/[^\/]+$/.test(request.site.name)) {
+        throw new Error("field request.site.name must match \"shippers/*/sites/*\", got: " + JSON.stringify(request.site.name));
+      }
+      const path = `v1/${encodePathSegments(request.site.name)}`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.site ?? {});
       const queryParams: string[] = [];
-      if (request.updateMask) {
-        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask.toString())}`)
+      if (request.updateMask !== undefined && request.updateMask !== null) {
+        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -825,13 +1282,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "UpdateSite",
-      }) as Promise<Site>;
+      }) as Promise<Site__Response>;
     },
     DeleteSite(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/sites\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*/sites/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -845,13 +1305,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "DeleteSite",
-      }) as Promise<Site>;
+      }) as Promise<Site__Response>;
     },
     GetShipment(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/shipments\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*/shipments/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -865,20 +1328,23 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "GetShipment",
-      }) as Promise<Shipment>;
+      }) as Promise<Shipment__Response>;
     },
     ListShipments(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.parent) {
         throw new Error("missing required field request.parent");
       }
-      const path = `v1/${request.parent}/shipments`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.parent)) {
+        throw new Error("field request.parent must match \"shippers/*\", got: " + JSON.stringify(request.parent));
+      }
+      const path = `v1/${encodePathSegments(request.parent)}/shipments`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
-      if (request.pageSize) {
-        queryParams.push(`pageSize=${encodeURIComponent(request.pageSize.toString())}`)
+      if (request.pageSize !== 0) {
+        queryParams.push(`pageSize=${encodeURIComponent(String(request.pageSize))}`)
       }
-      if (request.pageToken) {
-        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken.toString())}`)
+      if (request.pageToken !== "") {
+        queryParams.push(`pageToken=${encodeURIComponent(request.pageToken)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -891,13 +1357,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "ListShipments",
-      }) as Promise<ListShipmentsResponse>;
+      }) as Promise<ListShipmentsResponse__Response>;
     },
     CreateShipment(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.parent) {
         throw new Error("missing required field request.parent");
       }
-      const path = `v1/${request.parent}/shipments`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+$/.test(request.parent)) {
+        throw new Error("field request.parent must match \"shippers/*\", got: " + JSON.stringify(request.parent));
+      }
+      const path = `v1/${encodePathSegments(request.parent)}/shipments`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.shipment ?? {});
       const queryParams: string[] = [];
       let uri = path;
@@ -911,17 +1380,20 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "CreateShipment",
-      }) as Promise<Shipment>;
+      }) as Promise<Shipment__Response>;
     },
     UpdateShipment(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.shipment?.name) {
         throw new Error("missing required field request.shipment.name");
       }
-      const path = `v1/${request.shipment.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/shipments\/[^\/]+$/.test(request.shipment.name)) {
+        throw new Error("field request.shipment.name must match \"shippers/*/shipments/*\", got: " + JSON.stringify(request.shipment.name));
+      }
+      const path = `v1/${encodePathSegments(request.shipment.name)}`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.shipment ?? {});
       const queryParams: string[] = [];
-      if (request.updateMask) {
-        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask.toString())}`)
+      if (request.updateMask !== undefined && request.updateMask !== null) {
+        queryParams.push(`updateMask=${encodeURIComponent(request.updateMask)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -934,13 +1406,16 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "UpdateShipment",
-      }) as Promise<Shipment>;
+      }) as Promise<Shipment__Response>;
     },
     DeleteShipment(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.name) {
         throw new Error("missing required field request.name");
       }
-      const path = `v1/${request.name}`; // eslint-disable-line quotes
+      if (!/^shippers\/[^\/]+\/shipments\/[^\/]+$/.test(request.name)) {
+        throw new Error("field request.name must match \"shippers/*/shipments/*\", got: " + JSON.stringify(request.name));
+      }
+      const path = `v1/${encodePathSegments(request.name)}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -954,9 +1429,23 @@ export function createFreightServiceClient(
       }, {
         service: "FreightService",
         method: "DeleteShipment",
-      }) as Promise<Shipment>;
+      }) as Promise<Shipment__Response>;
     },
   };
 }
 
+/**
+ * Percent-encodes all characters except [-_.~0-9a-zA-Z].
+ */
+function encodePathSegment(value: string): string {
+  return encodeURIComponent(value).replace(/[!'()*]/g, (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`);
+}
+
+/**
+ * Percent-encodes all characters except [-_.~/0-9a-zA-Z].
+ */
+function encodePathSegments(value: string): string {
+  return value.split("/").map(encodePathSegment).join("/");
+}
+
 // @@protoc_insertion_point(typescript-http-eof)
@@ -1,6 +1,36 @@
 // Code generated by protoc-gen-typescript-http. DO NOT EDIT.
 /* eslint-disable camelcase */
-// @ts-nocheck
+
+/**
+ * If the Any contains a value that has a special JSON mapping,
+ * it will be converted as follows:
+ * {"@type": xxx, "value": yyy}.
+ * Otherwise, the value will be converted into a JSON object,
+ * and the "@type" field will be inserted to indicate the actual data type.
+ */
+interface wellKnownAny {
+  "@type": string;
+  [key: string]: unknown;
+}
+
+type wellKnownBoolValue = boolean | null;
+
+type wellKnownBytesValue = string | null;
+
+type wellKnownDoubleValue = number | null;
+
+/**
+ * Generated output always contains 0, 3, 6, or 9 fractional digits,
+ * depending on required precision, followed by the suffix "s".
+ * Accepted are any fractional digits (also none) as long as they fit
+ * into nano-seconds precision and the suffix "s" is required.
+ */
+type wellKnownDuration = string;
+
+/**
+ * An empty JSON object
+ */
+type wellKnownEmpty = Record<never, never>;
 
 /**
  * In JSON, a field mask is encoded as a single string where paths are
@@ -32,59 +62,42 @@
  */
 type wellKnownFieldMask = string;
 
-type wellKnownUInt32Value = number | null;
-
-/**
- * Generated output always contains 0, 3, 6, or 9 fractional digits,
- * depending on required precision, followed by the suffix "s".
- * Accepted are any fractional digits (also none) as long as they fit
- * into nano-seconds precision and the suffix "s" is required.
- */
-type wellKnownDuration = string;
-
 type wellKnownFloatValue = number | null;
 
-/**
- * An empty JSON object
- */
-type wellKnownEmpty = Record<never, never>;
+type wellKnownInt32Value = number | null;
+
+type wellKnownInt64Value = string | null;
 
 /**
  * Any JSON value.
  */
-type wellKnownStruct = Record<string, unknown>;
-
-type wellKnownValue = unknown;
-
-type wellKnownNullValue = null;
+type wellKnownJsonValue =
+  | null
+  | boolean
+  | number
+  | string
+  | wellKnownJsonValue[]
+  | { [key: string]: wellKnownJsonValue };
 
-type wellKnownBoolValue = boolean | null;
-
-type wellKnownDoubleValue = number | null;
+type wellKnownListValue = wellKnownJsonValue[];
 
-type wellKnownInt32Value = number | null;
+/**
+ * Encoded as null, while parsers also accept the name of the enum value.
+ */
+type wellKnownNullValue = "NULL_VALUE" | null;
 
-type wellKnownInt64Value = number | null;
+type wellKnownStringValue = string | null;
 
 /**
- * If the Any contains a value that has a special JSON mapping,
- * it will be converted as follows:
- * {"@type": xxx, "value": yyy}.
- * Otherwise, the value will be converted into a JSON object,
- * and the "@type" field will be inserted to indicate the actual data type.
+ * A JSON object.
  */
-interface wellKnownAny {
-  "@type": string;
-  [key: string]: unknown;
-}
+type wellKnownStruct = { [key: string]: wellKnownJsonValue };
 
-type wellKnownListValue = wellKnownValue[];
-
-type wellKnownBytesValue = string | null;
+type wellKnownUInt32Value = number | null;
 
-type wellKnownUInt64Value = number | null;
+type wellKnownUInt64Value = string | null;
 
-type wellKnownStringValue = string | null;
+type wellKnownValue = wellKnownJsonValue;
 
 /**
  * Enum
@@ -111,6 +124,7 @@ export type Message_NestedEnum =
    * NESTEDENUM_UNSPECIFIED
    */
   "NESTEDENUM_UNSPECIFIED";
+
 type RequestType = {
   path: string;
   method: string;
@@ -119,20 +133,10 @@ type RequestType = {
 
 type RequestHandler = (request: RequestType, meta: { service: string, method: string }) => Promise<unknown>;
 
-export type Request__Request = {
-  string: string;
-  repeatedString: string[];
-  nested: Request_Nested;
-};
-
-export type Request_Nested = {
-  string: string;
-};
-
 /**
  * Message
  */
-export type Message__Response = {
+export type Message = {
   /**
    * double
    */
@@ -148,7 +152,7 @@ export type Message__Response = {
   /**
    * int64
    */
-  int64: number;
+  int64: string;
   /**
    * uint32
    */
@@ -156,7 +160,7 @@ export type Message__Response = {
   /**
    * uint64
    */
-  uint64: number;
+  uint64: string;
   /**
    * sint32
    */
@@ -164,7 +168,7 @@ export type Message__Response = {
   /**
    * sint64
    */
-  sint64: number;
+  sint64: string;
   /**
    * fixed32
    */
@@ -172,7 +176,7 @@ export type Message__Response = {
   /**
    * fixed64
    */
-  fixed64: number;
+  fixed64: string;
   /**
    * sfixed32
    */
@@ -180,7 +184,7 @@ export type Message__Response = {
   /**
    * sfixed64
    */
-  sfixed64: number;
+  sfixed64: string;
   /**
    * bool
    */
@@ -200,7 +204,7 @@ export type Message__Response = {
   /**
    * message
    */
-  message: Message;
+  message?: Message;
   /**
    * optional double
    */
@@ -216,7 +220,7 @@ export type Message__Response = {
   /**
    * optional int64
    */
-  optionalInt64?: number;
+  optionalInt64?: string;
   /**
    * optional uint32
    */
@@ -224,7 +228,7 @@ export type Message__Response = {
   /**
    * optional uint64
    */
-  optionalUint64?: number;
+  optionalUint64?: string;
   /**
    * optional sint32
    */
@@ -232,7 +236,7 @@ export type Message__Response = {
   /**
    * optional sint64
    */
-  optionalSint64?: number;
+  optionalSint64?: string;
   /**
    * optional fixed32
    */
@@ -240,7 +244,7 @@ export type Message__Response = {
   /**
    * optional fixed64
    */
-  optionalFixed64?: number;
+  optionalFixed64?: string;
   /**
    * optional sfixed32
    */
@@ -248,7 +252,7 @@ export type Message__Response = {
   /**
    * optional sfixed64
    */
-  optionalSfixed64?: number;
+  optionalSfixed64?: string;
   /**
    * optional bool
    */
@@ -284,7 +288,7 @@ export type Message__Response = {
   /**
    * repeated_int64
    */
-  repeatedInt64: number[];
+  repeatedInt64: string[];
   /**
    * repeated_uint32
    */
@@ -292,7 +296,7 @@ export type Message__Response = {
   /**
    * repeated_uint64
    */
-  repeatedUint64: number[];
+  repeatedUint64: string[];
   /**
    * repeated_sint32
    */
@@ -300,7 +304,7 @@ export type Message__Response = {
   /**
    * repeated_sint64
    */
-  repeatedSint64: number[];
+  repeatedSint64: string[];
   /**
    * repeated_fixed32
    */
@@ -308,7 +312,7 @@ export type Message__Response = {
   /**
    * repeated_fixed64
    */
-  repeatedFixed64: number[];
+  repeatedFixed64: string[];
   /**
    * repeated_sfixed32
    */
@@ -316,7 +320,7 @@ export type Message__Response = {
   /**
    * repeated_sfixed64
    */
-  repeatedSfixed64: number[];
+  repeatedSfixed64: string[];
   /**
    * repeated_bool
    */
@@ -346,25 +350,409 @@ export type Message__Response = {
    */
   mapStringMessage: { [key: string]: Message };
   /**
-   * oneof_string
+   * any
+   */
+  any?: wellKnownAny;
+  /**
+   * repeated_any
+   */
+  repeatedAny: wellKnownAny[];
+  /**
+   * duration
+   */
+  duration?: wellKnownDuration;
+  /**
+   * repeated_duration
    */
-  oneofString?: string;
+  repeatedDuration: wellKnownDuration[];
   /**
-   * oneof_enum
+   * empty
    */
-  oneofEnum?: Enum;
+  empty?: wellKnownEmpty;
   /**
-   * oneof_message1
+   * repeated_empty
    */
-  oneofMessage1?: Message;
+  repeatedEmpty: wellKnownEmpty[];
   /**
-   * oneof_message2
+   * field_mask
+   */
+  fieldMask?: wellKnownFieldMask;
+  /**
+   * repeated_field_mask
+   */
+  repeatedFieldMask: wellKnownFieldMask[];
+  /**
+   * struct
    */
-  oneofMessage2?: Message;
+  struct?: wellKnownStruct;
+  /**
+   * repeated_struct
+   */
+  repeatedStruct: wellKnownStruct[];
+  /**
+   * value
+   */
+  value?: wellKnownValue;
+  /**
+   * repeated_value
+   */
+  repeatedValue: wellKnownValue[];
+  /**
+   * null_value
+   */
+  nullValue: wellKnownNullValue;
+  /**
+   * repeated_null_value
+   */
+  repeatedNullValue: wellKnownNullValue[];
+  /**
+   * list_value
+   */
+  listValue?: wellKnownListValue;
+  /**
+   * repeated_list_value
+   */
+  repeatedListValue: wellKnownListValue[];
+  /**
+   * bool_value
+   */
+  boolValue?: wellKnownBoolValue;
+  /**
+   * repeated_bool_value
+   */
+  repeatedBoolValue: wellKnownBoolValue[];
+  /**
+   * bytes_value
+   */
+  bytesValue?: wellKnownBytesValue;
+  /**
+   * repeated_bytes_value
+   */
+  repeatedBytesValue: wellKnownBytesValue[];
+  /**
+   * double_value
+   */
+  doubleValue?: wellKnownDoubleValue;
+  /**
+   * repeated_double_value
+   */
+  repeatedDoubleValue: wellKnownDoubleValue[];
+  /**
+   * float_value
+   */
+  floatValue?: wellKnownFloatValue;
+  /**
+   * repeated_float_value
+   */
+  repeatedFloatValue: wellKnownFloatValue[];
+  /**
+   * int32_value
+   */
+  int32Value?: wellKnownInt32Value;
+  /**
+   * repeated_int32_value
+   */
+  repeatedInt32Value: wellKnownInt32Value[];
+  /**
+   * int64_value
+   */
+  int64Value?: wellKnownInt64Value;
+  /**
+   * repeated_int64_value
+   */
+  repeatedInt64Value: wellKnownInt64Value[];
+  /**
+   * uint32_value
+   */
+  uint32Value?: wellKnownUInt32Value;
+  /**
+   * repeated_uint32_value
+   */
+  repeatedUint32Value: wellKnownUInt32Value[];
+  /**
+   * uint64_value
+   */
+  uint64Value?: wellKnownUInt64Value;
+  /**
+   * repeated_uint64_value
+   */
+  repeatedUint64Value: wellKnownUInt64Value[];
+  /**
+   * string_value
+   */
+  stringValue?: wellKnownUInt64Value;
+  /**
+   * repeated_string_value
+   */
+  repeatedStringValue: wellKnownStringValue[];
+} & (
+  /**
+   * oneof
+   */
+  | {
+    /**
+     * oneof_string
+     */
+    oneofString: string;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    /**
+     * oneof_enum
+     */
+    oneofEnum: Enum;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    /**
+     * oneof_message1
+     */
+    oneofMessage1: Message;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    /**
+     * oneof_message2
+     */
+    oneofMessage2: Message;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+);
+
+/**
+ * Message
+ */
+export type Message__Response = {
+  /**
+   * double
+   */
+  double: number;
+  /**
+   * float
+   */
+  float: number;
+  /**
+   * int32
+   */
+  int32: number;
+  /**
+   * int64
+   */
+  int64: string;
+  /**
+   * uint32
+   */
+  uint32: number;
+  /**
+   * uint64
+   */
+  uint64: string;
+  /**
+   * sint32
+   */
+  sint32: number;
+  /**
+   * sint64
+   */
+  sint64: string;
+  /**
+   * fixed32
+   */
+  fixed32: number;
+  /**
+   * fixed64
+   */
+  fixed64: string;
+  /**
+   * sfixed32
+   */
+  sfixed32: number;
+  /**
+   * sfixed64
+   */
+  sfixed64: string;
+  /**
+   * bool
+   */
+  bool: boolean;
+  /**
+   * string
+   */
+  string: string;
+  /**
+   * bytes
+   */
+  bytes: string;
+  /**
+   * enum
+   */
+  enum: Enum;
+  /**
+   * message
+   */
+  message?: Message;
+  /**
+   * optional double
+   */
+  optionalDouble?: number;
+  /**
+   * optional float
+   */
+  optionalFloat?: number;
+  /**
+   * optional int32
+   */
+  optionalInt32?: number;
+  /**
+   * optional int64
+   */
+  optionalInt64?: string;
+  /**
+   * optional uint32
+   */
+  optionalUint32?: number;
+  /**
+   * optional uint64
+   */
+  optionalUint64?: string;
+  /**
+   * optional sint32
+   */
+  optionalSint32?: number;
+  /**
+   * optional sint64
+   */
+  optionalSint64?: string;
+  /**
+   * optional fixed32
+   */
+  optionalFixed32?: number;
+  /**
+   * optional fixed64
+   */
+  optionalFixed64?: string;
+  /**
+   * optional sfixed32
+   */
+  optionalSfixed32?: number;
+  /**
+   * optional sfixed64
+   */
+  optionalSfixed64?: string;
+  /**
+   * optional bool
+   */
+  optionalBool?: boolean;
+  /**
+   * optional string
+   */
+  optionalString?: string;
+  /**
+   * optional bytes
+   */
+  optionalBytes?: string;
+  /**
+   * optional enum
+   */
+  optionalEnum?: Enum;
+  /**
+   * optional message
+   */
+  optionalMessage?: Message;
+  /**
+   * repeated_double
+   */
+  repeatedDouble: number[];
+  /**
+   * repeated_float
+   */
+  repeatedFloat: number[];
+  /**
+   * repeated_int32
+   */
+  repeatedInt32: number[];
+  /**
+   * repeated_int64
+   */
+  repeatedInt64: string[];
+  /**
+   * repeated_uint32
+   */
+  repeatedUint32: number[];
+  /**
+   * repeated_uint64
+   */
+  repeatedUint64: string[];
+  /**
+   * repeated_sint32
+   */
+  repeatedSint32: number[];
+  /**
+   * repeated_sint64
+   */
+  repeatedSint64: string[];
+  /**
+   * repeated_fixed32
+   */
+  repeatedFixed32: number[];
+  /**
+   * repeated_fixed64
+   */
+  repeatedFixed64: string[];
+  /**
+   * repeated_sfixed32
+   */
+  repeatedSfixed32: number[];
+  /**
+   * repeated_sfixed64
+   */
+  repeatedSfixed64: string[];
+  /**
+   * repeated_bool
+   */
+  repeatedBool: boolean[];
+  /**
+   * repeated_string
+   */
+  repeatedString: string[];
+  /**
+   * repeated_bytes
+   */
+  repeatedBytes: string[];
+  /**
+   * repeated_enum
+   */
+  repeatedEnum: Enum[];
+  /**
+   * repeated_message
+   */
+  repeatedMessage: Message[];
+  /**
+   * map_string_string
+   */
+  mapStringString: { [key: string]: string };
+  /**
+   * map_string_message
+   */
+  mapStringMessage: { [key: string]: Message };
   /**
    * any
    */
-  any: wellKnownAny;
+  any?: wellKnownAny;
   /**
    * repeated_any
    */
@@ -372,7 +760,7 @@ export type Message__Response = {
   /**
    * duration
    */
-  duration: wellKnownDuration;
+  duration?: wellKnownDuration;
   /**
    * repeated_duration
    */
@@ -380,7 +768,7 @@ export type Message__Response = {
   /**
    * empty
    */
-  empty: wellKnownEmpty;
+  empty?: wellKnownEmpty;
   /**
    * repeated_empty
    */
@@ -388,7 +776,7 @@ export type Message__Response = {
   /**
    * field_mask
    */
-  fieldMask: wellKnownFieldMask;
+  fieldMask?: wellKnownFieldMask;
   /**
    * repeated_field_mask
    */
@@ -396,7 +784,7 @@ export type Message__Response = {
   /**
    * struct
    */
-  struct: wellKnownStruct;
+  struct?: wellKnownStruct;
   /**
    * repeated_struct
    */
@@ -404,7 +792,7 @@ export type Message__Response = {
   /**
    * value
    */
-  value: wellKnownValue;
+  value?: wellKnownValue;
   /**
    * repeated_value
    */
@@ -420,7 +808,7 @@ export type Message__Response = {
   /**
    * list_value
    */
-  listValue: wellKnownListValue;
+  listValue?: wellKnownListValue;
   /**
    * repeated_list_value
    */
@@ -428,7 +816,7 @@ export type Message__Response = {
   /**
    * bool_value
    */
-  boolValue: wellKnownBoolValue;
+  boolValue?: wellKnownBoolValue;
   /**
    * repeated_bool_value
    */
@@ -436,7 +824,7 @@ export type Message__Response = {
   /**
    * bytes_value
    */
-  bytesValue: wellKnownBytesValue;
+  bytesValue?: wellKnownBytesValue;
   /**
    * repeated_bytes_value
    */
@@ -444,7 +832,7 @@ export type Message__Response = {
   /**
    * double_value
    */
-  doubleValue: wellKnownDoubleValue;
+  doubleValue?: wellKnownDoubleValue;
   /**
    * repeated_double_value
    */
@@ -452,7 +840,7 @@ export type Message__Response = {
   /**
    * float_value
    */
-  floatValue: wellKnownFloatValue;
+  floatValue?: wellKnownFloatValue;
   /**
    * repeated_float_value
    */
@@ -460,7 +848,7 @@ export type Message__Response = {
   /**
    * int32_value
    */
-  int32Value: wellKnownInt32Value;
+  int32Value?: wellKnownInt32Value;
   /**
    * repeated_int32_value
    */
@@ -468,7 +856,7 @@ export type Message__Response = {
   /**
    * int64_value
    */
-  int64Value: wellKnownInt64Value;
+  int64Value?: wellKnownInt64Value;
   /**
    * repeated_int64_value
    */
@@ -476,7 +864,7 @@ export type Message__Response = {
   /**
    * uint32_value
    */
-  uint32Value: wellKnownUInt32Value;
+  uint32Value?: wellKnownUInt32Value;
   /**
    * repeated_uint32_value
    */
@@ -484,7 +872,7 @@ export type Message__Response = {
   /**
    * uint64_value
    */
-  uint64Value: wellKnownUInt64Value;
+  uint64Value?: wellKnownUInt64Value;
   /**
    * repeated_uint64_value
    */
@@ -492,12 +880,58 @@ export type Message__Response = {
   /**
    * string_value
    */
-  stringValue: wellKnownUInt64Value;
+  stringValue?: wellKnownUInt64Value;
   /**
    * repeated_string_value
    */
   repeatedStringValue: wellKnownStringValue[];
-};
+} & (
+  /**
+   * oneof
+   */
+  | {
+    /**
+     * oneof_string
+     */
+    oneofString: string;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    /**
+     * oneof_enum
+     */
+    oneofEnum: Enum;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    /**
+     * oneof_message1
+     */
+    oneofMessage1: Message;
+    oneofMessage2?: never;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    /**
+     * oneof_message2
+     */
+    oneofMessage2: Message;
+  }
+  | {
+    oneofString?: never;
+    oneofEnum?: never;
+    oneofMessage1?: never;
+    oneofMessage2?: never;
+  }
+);
 
 /**
  * NestedMessage
@@ -509,6 +943,22 @@ export type Message_NestedMessage = {
   string: string;
 };
 
+export type Request = {
+  string: string;
+  repeatedString: string[];
+  nested?: Request_Nested;
+};
+
+export type Request__Request = {
+  string: string;
+  repeatedString: string[];
+  nested: Request_Nested;
+};
+
+export type Request_Nested = {
+  string: string;
+};
+
 export interface SyntaxService {
   QueryOnly(request: Request__Request): Promise<Message__Response>;
   EmptyVerb(request: wellKnownEmpty): Promise<wellKnownEmpty>;
@@ -526,16 +976,16 @@ export function createSyntaxServiceClient(
       const path = `v1`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
-      if (request.string) {
-        queryParams.push(`string=${encodeURIComponent(request.string.toString())}`)
+      if (request.string !== "") {
+        queryParams.push(`string=${encodeURIComponent(request.string)}`)
       }
       if (request.repeatedString) {
         request.repeatedString.forEach((x) => {
-          queryParams.push(`repeatedString=${encodeURIComponent(x.toString())}`)
+          queryParams.push(`repeatedString=${encodeURIComponent(x)}`)
         })
       }
-      if (request.nested?.string) {
-        queryParams.push(`nested.string=${encodeURIComponent(request.nested.string.toString())}`)
+      if (request.nested?.string !== undefined && request.nested?.string !== "") {
+        queryParams.push(`nested.string=${encodeURIComponent(request.nested.string)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -548,7 +998,7 @@ export function createSyntaxServiceClient(
       }, {
         service: "SyntaxService",
         method: "QueryOnly",
-      }) as Promise<Message>;
+      }) as Promise<Message__Response>;
     },
     EmptyVerb(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       const path = `v1:emptyVerb`; // eslint-disable-line quotes
@@ -582,18 +1032,18 @@ export function createSyntaxServiceClient(
       }, {
         service: "SyntaxService",
         method: "StarBody",
-      }) as Promise<Message>;
+      }) as Promise<Message__Response>;
     },
     Body(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       const path = `v1:body`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.nested ?? {});
       const queryParams: string[] = [];
-      if (request.string) {
-        queryParams.push(`string=${encodeURIComponent(request.string.toString())}`)
+      if (request.string !== "") {
+        queryParams.push(`string=${encodeURIComponent(request.string)}`)
       }
       if (request.repeatedString) {
         request.repeatedString.forEach((x) => {
-          queryParams.push(`repeatedString=${encodeURIComponent(x.toString())}`)
+          queryParams.push(`repeatedString=${encodeURIComponent(x)}`)
         })
       }
       let uri = path;
@@ -607,22 +1057,22 @@ export function createSyntaxServiceClient(
       }, {
         service: "SyntaxService",
         method: "Body",
-      }) as Promise<Message>;
+      }) as Promise<Message__Response>;
     },
     Path(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.string) {
         throw new Error("missing required field request.string");
       }
-      const path = `v1/${request.string}:path`; // eslint-disable-line quotes
+      const path = `v1/${encodePathSegment(request.string)}:path`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       if (request.repeatedString) {
         request.repeatedString.forEach((x) => {
-          queryParams.push(`repeatedString=${encodeURIComponent(x.toString())}`)
+          queryParams.push(`repeatedString=${encodeURIComponent(x)}`)
         })
       }
-      if (request.nested?.string) {
-        queryParams.push(`nested.string=${encodeURIComponent(request.nested.string.toString())}`)
+      if (request.nested?.string !== undefined && request.nested?.string !== "") {
+        queryParams.push(`nested.string=${encodeURIComponent(request.nested.string)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -635,18 +1085,18 @@ export function createSyntaxServiceClient(
       }, {
         service: "SyntaxService",
         method: "Path",
-      }) as Promise<Message>;
+      }) as Promise<Message__Response>;
     },
     PathBody(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.string) {
         throw new Error("missing required field request.string");
       }
-      const path = `v1/${request.string}:pathBody`; // eslint-disable-line quotes
+      const path = `v1/${encodePathSegment(request.string)}:pathBody`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.nested ?? {});
       const queryParams: string[] = [];
       if (request.repeatedString) {
         request.repeatedString.forEach((x) => {
-          queryParams.push(`repeatedString=${encodeURIComponent(x.toString())}`)
+          queryParams.push(`repeatedString=${encodeURIComponent(x)}`)
         })
       }
       let uri = path;
@@ -660,9 +1110,16 @@ export function createSyntaxServiceClient(
       }, {
         service: "SyntaxService",
         method: "PathBody",
-      }) as Promise<Message>;
+      }) as Promise<Message__Response>;
     },
   };
 }
 
+/**
+ * Percent-encodes all characters except [-_.~0-9a-zA-Z].
+ */
+function encodePathSegment(value: string): string {
+  return encodeURIComponent(value).replace(/[!'()*]/g, (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`);
+}
+
 // @@protoc_insertion_point(typescript-http-eof)
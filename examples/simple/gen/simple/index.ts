@@ -1,20 +1,19 @@
 // Code generated by protoc-gen-typescript-http. DO NOT EDIT.
 /* eslint-disable camelcase */
-// @ts-nocheck
 
 /**
- * Encoded using RFC 3339, where generated output will always be Z-normalized
- * and uses 0, 3, 6 or 9 fractional digits.
- * Offsets other than "Z" are also accepted.
+ * An empty JSON object
  */
-type wellKnownTimestamp = string;
+type wellKnownEmpty = Record<never, never>;
 
 type wellKnownStringValue = string | null;
 
 /**
- * An empty JSON object
+ * Encoded using RFC 3339, where generated output will always be Z-normalized
+ * and uses 0, 3, 6 or 9 fractional digits.
+ * Offsets other than "Z" are also accepted.
  */
-type wellKnownEmpty = Record<never, never>;
+type wellKnownTimestamp = string;
 
 type RequestType = {
   path: string;
@@ -39,7 +38,31 @@ export type CreateElementRequest = {
    * 
    * Behaviors: REQUIRED
    */
-  element: Element;
+  element?: Element;
+  /**
+   * The ID to use for the element
+   * 
+   * Behaviors: OPTIONAL
+   */
+  elementId: string;
+};
+
+/**
+ * Request message for creating an element
+ */
+export type CreateElementRequest__Request = {
+  /**
+   * The parent resource where the element will be created
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * The element to create
+   * 
+   * Behaviors: REQUIRED
+   */
+  element: Element__Request;
   /**
    * The ID to use for the element
    * 
@@ -48,6 +71,80 @@ export type CreateElementRequest = {
   elementId?: string;
 };
 
+/**
+ * Request message for creating an element
+ */
+export type CreateElementRequest__Response = {
+  /**
+   * The parent resource where the element will be created
+   * 
+   * Behaviors: REQUIRED
+   */
+  parent: string;
+  /**
+   * The element to create
+   * 
+   * Behaviors: REQUIRED
+   */
+  element?: Element__Response;
+  /**
+   * The ID to use for the element
+   * 
+   * Behaviors: OPTIONAL
+   */
+  elementId: string;
+};
+
+/**
+ * Request message for CreateUser.
+ */
+export type CreateUserRequest = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  user?: User;
+};
+
+/**
+ * Request message for CreateUser.
+ */
+export type CreateUserRequest__Request = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  user: User__Request;
+};
+
+/**
+ * Request message for CreateUser.
+ */
+export type CreateUserRequest__Response = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  user?: User__Response;
+};
+
+/**
+ * Request message for DeleteUser.
+ */
+export type DeleteUserRequest = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  id: number;
+};
+
+/**
+ * Request message for DeleteUser.
+ */
+export type DeleteUserRequest__Request = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  id: number;
+};
+
 export type Element = {
   /**
    * The rsource name of the element.
@@ -68,35 +165,128 @@ export type Element = {
    * Behaviors: REQUIRED
    */
   inputs: string[];
+  /**
+   * whether the element referenced by input[i] is from a discard list
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  inputIsDiscard: boolean[];
+  /**
+   * The labels of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  labels: string[];
+  /**
+   * The created date of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  createTime?: wellKnownTimestamp;
+  /**
+   * The last edited date of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  updateTime?: wellKnownTimestamp;
   /**
    * element's description field.
    * 
    * Behaviors: OPTIONAL
    */
   description?: wellKnownStringValue;
+  /**
+   * the pipeline canvas sid this element belongs to
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  pipelineCanvasSid: string;
 };
 
-/**
- * A simple message representing a user.
- */
-export type User = {
+export type Element__Request = {
   /**
-   * Behaviors: OUTPUT_ONLY
+   * The rsource name of the element.
+   * Format: orgs/{org}/elements/{element}
+   * 
+   * Behaviors: IDENTIFIER
    */
-  id: number;
+  name: string;
+  /**
+   * The human-readable title of the element.
+   * 
+   * Behaviors: REQUIRED
+   */
+  title: string;
   /**
+   * the input element_ids that input to this node
+   * 
    * Behaviors: REQUIRED
    */
+  inputs: string[];
+  /**
+   * element's description field.
+   * 
+   * Behaviors: OPTIONAL
+   */
+  description?: wellKnownStringValue;
+};
+
+export type Element__Response = {
+  /**
+   * The rsource name of the element.
+   * Format: orgs/{org}/elements/{element}
+   * 
+   * Behaviors: IDENTIFIER
+   */
   name: string;
-  email: string;
   /**
+   * The human-readable title of the element.
+   * 
+   * Behaviors: REQUIRED
+   */
+  title: string;
+  /**
+   * the input element_ids that input to this node
+   * 
+   * Behaviors: REQUIRED
+   */
+  inputs: string[];
+  /**
+   * whether the element referenced by input[i] is from a discard list
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  inputIsDiscard: boolean[];
+  /**
+   * The labels of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  labels: string[];
+  /**
+   * The created date of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  createTime?: wellKnownTimestamp;
+  /**
+   * The last edited date of the element.
+   * 
+   * Behaviors: OUTPUT_ONLY
+   */
+  updateTime?: wellKnownTimestamp;
+  /**
+   * element's description field.
+   * 
    * Behaviors: OPTIONAL
    */
-  favoriteColor: string;
+  description?: wellKnownStringValue;
   /**
+   * the pipeline canvas sid this element belongs to
+   * 
    * Behaviors: OUTPUT_ONLY
    */
-  createdDate: wellKnownTimestamp;
+  pipelineCanvasSid: string;
 };
 
 /**
@@ -107,26 +297,71 @@ export type GetUserRequest = {
 };
 
 /**
- * Request message for CreateUser.
+ * Request message for GetUser.
  */
-export type CreateUserRequest = {
+export type GetUserRequest__Request = {
+  id: number;
+};
+
+/**
+ * A simple message representing a user.
+ */
+export type User = {
+  /**
+   * Behaviors: OUTPUT_ONLY
+   */
+  id: number;
   /**
    * Behaviors: REQUIRED
    */
-  user: User;
+  name: string;
+  email: string;
+  /**
+   * Behaviors: OPTIONAL
+   */
+  favoriteColor: string;
+  /**
+   * Behaviors: OUTPUT_ONLY
+   */
+  createdDate?: wellKnownTimestamp;
 };
 
 /**
  * A simple message representing a user.
  */
+export type User__Request = {
+  /**
+   * Behaviors: REQUIRED
+   */
+  name: string;
+  email: string;
+  /**
+   * Behaviors: OPTIONAL
+   */
+  favoriteColor?: string;
+};
+
 /**
- * Request message for DeleteUser.
+ * A simple message representing a user.
  */
-export type DeleteUserRequest = {
+export type User__Response = {
   /**
-   * Behaviors: REQUIRED
+   * Behaviors: OUTPUT_ONLY
    */
   id: number;
+  /**
+   * Behaviors: REQUIRED
+   */
+  name: string;
+  email: string;
+  /**
+   * Behaviors: OPTIONAL
+   */
+  favoriteColor: string;
+  /**
+   * Behaviors: OUTPUT_ONLY
+   */
+  createdDate?: wellKnownTimestamp;
 };
 
 /**
@@ -136,7 +371,7 @@ export interface ElementService {
   /**
    * CreateElement creates a new pipeline element
    */
-  CreateElement(request: CreateElementRequest): Promise<Element>;
+  CreateElement(request: CreateElementRequest__Request): Promise<Element__Response>;
 }
 
 export function createElementServiceClient(
@@ -147,11 +382,14 @@ export function createElementServiceClient(
       if (!request.parent) {
         throw new Error("missing required field request.parent");
       }
-      const path = `tcn/lms/element/v1alpha1/${request.parent}/elements`; // eslint-disable-line quotes
+      if (!/^orgs\/[^\/]+$/.test(request.parent)) {
+        throw new Error("field request.parent must match \"orgs/*\", got: " + JSON.stringify(request.parent));
+      }
+      const path = `tcn/lms/element/v1alpha1/${encodePathSegments(request.parent)}/elements`; // eslint-disable-line quotes
       const body = JSON.stringify(request?.element ?? {});
       const queryParams: string[] = [];
-      if (request.elementId) {
-        queryParams.push(`elementId=${encodeURIComponent(request.elementId.toString())}`)
+      if (request.elementId !== undefined && request.elementId !== "") {
+        queryParams.push(`elementId=${encodeURIComponent(request.elementId)}`)
       }
       let uri = path;
       if (queryParams.length > 0) {
@@ -164,7 +402,7 @@ export function createElementServiceClient(
       }, {
         service: "ElementService",
         method: "CreateElement",
-      }) as Promise<Element>;
+      }) as Promise<Element__Response>;
     },
   };
 }
@@ -175,12 +413,12 @@ export interface UserService {
   /**
    * Gets a user by ID.
    */
-  GetUser(request: GetUserRequest): Promise<User>;
+  GetUser(request: GetUserRequest__Request): Promise<User__Response>;
   /**
    * Creates a new user.
    */
-  CreateUser(request: CreateUserRequest): Promise<User>;
-  DeleteUser(request: DeleteUserRequest): Promise<wellKnownEmpty>;
+  CreateUser(request: CreateUserRequest__Request): Promise<User__Response>;
+  DeleteUser(request: DeleteUserRequest__Request): Promise<wellKnownEmpty>;
 }
 
 export function createUserServiceClient(
@@ -191,7 +429,10 @@ export function createUserServiceClient(
       if (!request.id) {
         throw new Error("missing required field request.id");
       }
-      const path = `api/${request.id}`; // eslint-disable-line quotes
+      if (!/^users\/[^\/]+$/.test(String(request.id))) {
+        throw new Error("field request.id must match \"users/*\", got: " + JSON.stringify(String(request.id)));
+      }
+      const path = `api/${encodePathSegments(String(request.id))}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -205,7 +446,7 @@ export function createUserServiceClient(
       }, {
         service: "UserService",
         method: "GetUser",
-      }) as Promise<User>;
+      }) as Promise<User__Response>;
     },
     CreateUser(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       const path = `api/users`; // eslint-disable-line quotes
@@ -222,13 +463,16 @@ export function createUserServiceClient(
       }, {
         service: "UserService",
         method: "CreateUser",
-      }) as Promise<User>;
+      }) as Promise<User__Response>;
     },
     DeleteUser(request) { // eslint-disable-line @typescript-eslint/no-unused-vars
       if (!request.id) {
         throw new Error("missing required field request.id");
       }
-      const path = `api/${request.id}`; // eslint-disable-line quotes
+      if (!/^users\/[^\/]+$/.test(String(request.id))) {
+        throw new Error("field request.id must match \"users/*\", got: " + JSON.stringify(String(request.id)));
+      }
+      const path = `api/${encodePathSegments(String(request.id))}`; // eslint-disable-line quotes
       const body = null;
       const queryParams: string[] = [];
       let uri = path;
@@ -247,4 +491,18 @@ export function createUserServiceClient(
   };
 }
 
+/**
+ * Percent-encodes all characters except [-_.~0-9a-zA-Z].
+ */
+function encodePathSegment(value: string): string {
+  return encodeURIComponent(value).replace(/[!'()*]/g, (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`);
+}
+
+/**
+ * Percent-encodes all characters except [-_.~/0-9a-zA-Z].
+ */
+function encodePathSegments(value: string): string {
+  return value.split("/").map(encodePathSegment).join("/");
+}
+
 // @@protoc_insertion_point(typescript-http-eof)
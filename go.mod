@@ -3,9 +3,14 @@ module github.com/evad1n/protoc-gen-typescript-http
 go 1.22
 
 require (
+	github.com/bufbuild/protocompile v0.14.1
+	google.golang.org/genproto v0.0.0-20240624140628-dc46fd24d27d
 	google.golang.org/genproto/googleapis/api v0.0.0-20240624140628-dc46fd24d27d
 	google.golang.org/protobuf v1.34.2
 	gotest.tools/v3 v3.5.1
 )
 
-require github.com/google/go-cmp v0.5.9 // indirect
+require (
+	github.com/google/go-cmp v0.6.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
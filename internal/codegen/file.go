@@ -19,3 +19,8 @@ func (f *File) Write(v ...interface{}) {
 func (f *File) Content() []byte {
 	return f.buf.Bytes()
 }
+
+// Append writes the content of another file.
+func (f *File) Append(other *File) {
+	f.buf.Write(other.Content())
+}
@@ -21,8 +21,11 @@ type Rule struct {
 	// The HTTP method to use.
 	Method string
 	// The template describing the URL to use.
-	Template        Template
-	Body            string
+	Template Template
+	Body     string
+	// The name of the response field whose value is the HTTP response body.
+	// When empty, the entire response message is the body.
+	ResponseBody    string
 	AdditionalRules []Rule
 }
 
@@ -51,6 +54,7 @@ func ParseRule(httpRule *annotations.HttpRule) (Rule, error) {
 		Method:          method,
 		Template:        template,
 		Body:            httpRule.GetBody(),
+		ResponseBody:    httpRule.GetResponseBody(),
 		AdditionalRules: additional,
 	}, nil
 }
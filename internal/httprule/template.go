@@ -1,6 +1,9 @@
 package httprule
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Template represents a http path template.
 //
@@ -32,6 +35,53 @@ type VariableSegment struct {
 	Segments  []Segment
 }
 
+// String returns the template as written in a http rule.
+func (t Template) String() string {
+	s := "/" + segmentsString(t.Segments)
+	if t.Verb != "" {
+		s += ":" + t.Verb
+	}
+	return s
+}
+
+// String returns the segment as written in a template.
+func (s Segment) String() string {
+	switch s.Kind {
+	case SegmentKindLiteral:
+		return s.Literal
+	case SegmentKindMatchSingle:
+		return "*"
+	case SegmentKindMatchMultiple:
+		return "**"
+	case SegmentKindVariable:
+		return "{" + s.Variable.String() + "}"
+	default:
+		return ""
+	}
+}
+
+// String returns the variable as written in a template, without braces.
+// The segments are omitted when the variable matches a single segment.
+func (v VariableSegment) String() string {
+	if len(v.Segments) == 1 && v.Segments[0].Kind == SegmentKindMatchSingle {
+		return v.FieldPath.String()
+	}
+	return v.FieldPath.String() + "=" + v.SegmentsString()
+}
+
+// SegmentsString returns the sub-template that values of the variable must match, such as `shippers/*`.
+func (v VariableSegment) SegmentsString() string {
+	return segmentsString(v.Segments)
+}
+
+func segmentsString(segments []Segment) string {
+	parts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, "/")
+}
+
 func ParseTemplate(s string) (Template, error) {
 	p := &parser{
 		content: s,
@@ -149,6 +149,7 @@ func Test_ParseTemplate(t *testing.T) {
 			got, err := ParseTemplate(tt.input)
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.path, got)
+			assert.Equal(t, tt.input, got.String())
 		})
 	}
 }
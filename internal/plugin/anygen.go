@@ -0,0 +1,137 @@
+package plugin
+
+import (
+	"strconv"
+
+	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const typeURLPrefix = "type.googleapis.com/"
+
+// wellKnownAnyValueTypes are the well known types with a special JSON mapping, which are
+// packed into an Any as {"@type": xxx, "value": yyy}.
+var wellKnownAnyValueTypes = []WellKnown{
+	WellKnownAny,
+	WellKnownDuration,
+	WellKnownFieldMask,
+	WellKnownStruct,
+	WellKnownTimestamp,
+	WellKnownValue,
+	WellKnownListValue,
+	WellKnownFloatValue,
+	WellKnownInt64Value,
+	WellKnownInt32Value,
+	WellKnownUInt64Value,
+	WellKnownUInt32Value,
+	WellKnownBytesValue,
+	WellKnownDoubleValue,
+	WellKnownBoolValue,
+	WellKnownStringValue,
+}
+
+// registerAnyMessages determines the messages that a typed Any may contain in each package: the
+// messages of the package and of the packages it imports, directly or indirectly, that are generated
+// in the same invocation. They are referenced by their default type.
+func registerAnyMessages(packages []*packageGenerator) {
+	generated := make(map[protoreflect.FullName]*packageGenerator, len(packages))
+	for _, p := range packages {
+		generated[p.name] = p
+	}
+	for _, p := range packages {
+		for _, name := range sortedKeys(p.importedPackages()) {
+			imported, ok := generated[name]
+			if !ok {
+				continue
+			}
+			for _, message := range sortedKeys(imported.messageRegistry) {
+				p.anyMessages = append(p.anyMessages, imported.messageRegistry[message])
+			}
+		}
+	}
+}
+
+// importedPackages returns the package and the packages that its files import, directly or indirectly.
+func (p *packageGenerator) importedPackages() map[protoreflect.FullName]struct{} {
+	packages := map[protoreflect.FullName]struct{}{p.name: {}}
+	visited := make(map[string]bool)
+	var visit func(file protoreflect.FileDescriptor)
+	visit = func(file protoreflect.FileDescriptor) {
+		if visited[file.Path()] {
+			return
+		}
+		visited[file.Path()] = true
+		packages[file.Package()] = struct{}{}
+		for i := 0; i < file.Imports().Len(); i++ {
+			visit(file.Imports().Get(i).FileDescriptor)
+		}
+	}
+	for _, file := range p.files {
+		visit(file)
+	}
+	return packages
+}
+
+// generateTypedAny generates the declaration of Any as a union of the messages of the package and
+// of the packages it imports, discriminated by their type URL, together with the functions packing
+// and unpacking it.
+func (p *packageGenerator) generateTypedAny(f *codegen.File) {
+	typesName := WellKnownAny.Name() + "Types"
+	f.Write("/**")
+	f.Write(" * The types that a ", WellKnownAny.Name(), " may contain, by type URL.")
+	f.Write(" * Well known types with a special JSON mapping are contained in a value field.")
+	f.Write(" */")
+	f.Write("type ", typesName, " = {")
+	for _, message := range p.anyMessages {
+		f.Write(indentBy(1), strconv.Quote(typeURLPrefix+string(message.FullName())), ": ", p.typeFromMessage(message, defaultVariant).Reference(), ";")
+	}
+	f.Write(indentBy(1), strconv.Quote(typeURLPrefix+string(WellKnownEmpty)), ": ", p.wellKnownTypeName(WellKnownEmpty), ";")
+	for _, wkt := range wellKnownAnyValueTypes {
+		// Values of an Any are not converted by clients, so they keep their JSON representation
+		valueType := "string"
+		if !p.options.convertsWellKnownType(wkt) {
+			valueType = p.wellKnownTypeName(wkt)
+		}
+		f.Write(indentBy(1), strconv.Quote(typeURLPrefix+string(wkt)), ": { value: ", valueType, " };")
+	}
+	f.Write("};")
+	f.Write()
+
+	f.Write("/**")
+	f.Write(" * If the Any contains a value that has a special JSON mapping,")
+	f.Write(" * it will be converted as follows:")
+	f.Write(" * {\"@type\": xxx, \"value\": yyy}.")
+	f.Write(" * Otherwise, the value will be converted into a JSON object,")
+	f.Write(" * and the \"@type\" field will be inserted to indicate the actual data type.")
+	f.Write(" */")
+	f.Write("type ", WellKnownAny.Name(), " = {")
+	f.Write(indentBy(1), "[U in keyof ", typesName, "]: { \"@type\": U } & ", typesName, "[U];")
+	f.Write("}[keyof ", typesName, "];")
+	f.Write()
+
+	f.Write("/**")
+	f.Write(" * Packs a message into an Any.")
+	f.Write(" */")
+	f.Write("export function packAny<U extends keyof ", typesName, ">(typeUrl: U, message: ", typesName, "[U]): ", WellKnownAny.Name(), " {")
+	f.Write(indentBy(1), "return { ...message, \"@type\": typeUrl } as ", WellKnownAny.Name(), ";")
+	f.Write("}")
+	f.Write()
+
+	f.Write("/**")
+	f.Write(" * Unpacks a message from an Any, or returns undefined if the Any contains another type.")
+	f.Write(" */")
+	f.Write("export function unpackAny<U extends keyof ", typesName, ">(any: ", WellKnownAny.Name(), ", typeUrl: U): ", typesName, "[U] | undefined {")
+	f.Write(indentBy(1), "if (any[\"@type\"] !== typeUrl) {")
+	f.Write(indentBy(2), "return undefined;")
+	f.Write(indentBy(1), "}")
+	f.Write(indentBy(1), "const { \"@type\": _, ...message } = any; // eslint-disable-line @typescript-eslint/no-unused-vars")
+	f.Write(indentBy(1), "return message as unknown as ", typesName, "[U];")
+	f.Write("}")
+	f.Write()
+}
+
+// wellKnownTypeName returns the name of a well known type, registering it to be declared in the file.
+func (p *packageGenerator) wellKnownTypeName(wkt WellKnown) string {
+	p.registerWellKnownType(wkt)
+	return wkt.Name()
+}
@@ -0,0 +1,152 @@
+package plugin
+
+import (
+	"strings"
+
+	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// With the int64=bigint, timestamp and duration options, clients convert 64-bit integers, Timestamp
+// and Duration values between their JSON representation and the configured types. Messages
+// containing them are converted by the decode and encode transforms, which call the helpers
+// generated by generateCodecHelpers for well known types.
+
+// convertsWellKnownType reports whether values of a well known type are converted by clients.
+func (o generatorOptions) convertsWellKnownType(wkt WellKnown) bool {
+	switch wkt {
+	case WellKnownTimestamp:
+		return o.timestamp != timestampAsString
+	case WellKnownDuration:
+		return o.duration != durationAsString
+	case WellKnownInt64Value, WellKnownUInt64Value:
+		return o.int64 == int64AsBigint
+	}
+	return false
+}
+
+// codecFields returns a predicate matching the fields whose values are converted by clients.
+func (o generatorOptions) codecFields() func(field protoreflect.FieldDescriptor) bool {
+	return func(field protoreflect.FieldDescriptor) bool {
+		if isInt64Field(field) {
+			return o.int64Type(field) == int64AsBigint
+		}
+		wkt, ok := WellKnownType(field.Message())
+		return ok && o.convertsWellKnownType(wkt)
+	}
+}
+
+// decodeTransform converts responses from their JSON representation.
+func decodeTransform(o generatorOptions) messageTransform {
+	return messageTransform{
+		prefix:  "decode",
+		variant: responseVariant,
+		doc: []string{
+			"Converts a response from its JSON representation, decoding the values configured",
+			"by the int64, timestamp and duration options, recursively.",
+		},
+		converts: o.codecFields(),
+		convert: func(p *packageGenerator, field protoreflect.FieldDescriptor, value string) string {
+			if isInt64Field(field) {
+				return "BigInt(" + value + ")"
+			}
+			wkt, _ := WellKnownType(field.Message())
+			return p.codecHelper(decoderName(wkt)) + "(" + value + ")"
+		},
+		inputType: "any",
+	}
+}
+
+// encodeTransform converts requests to their JSON representation.
+func encodeTransform(o generatorOptions) messageTransform {
+	return messageTransform{
+		prefix:  "encode",
+		variant: requestVariant,
+		doc: []string{
+			"Converts a request to its JSON representation, encoding the values configured",
+			"by the int64, timestamp and duration options, recursively.",
+		},
+		converts: o.codecFields(),
+		convert: func(p *packageGenerator, field protoreflect.FieldDescriptor, value string) string {
+			if isInt64Field(field) {
+				return "String(" + value + ")"
+			}
+			wkt, _ := WellKnownType(field.Message())
+			return p.codecHelper(encoderName(wkt)) + "(" + value + ")"
+		},
+		outputType: "unknown",
+	}
+}
+
+func decoderName(wkt WellKnown) string {
+	return "decode" + strings.ToUpper(wkt.Name()[:1]) + wkt.Name()[1:]
+}
+
+func encoderName(wkt WellKnown) string {
+	return "encode" + strings.ToUpper(wkt.Name()[:1]) + wkt.Name()[1:]
+}
+
+// codecHelper returns the name of a helper converting a well known type, registering it to be generated.
+func (p *packageGenerator) codecHelper(name string) string {
+	p.codecHelpers[name] = struct{}{}
+	return name
+}
+
+// generateCodecHelpers generates the helpers used by the decode and encode transforms.
+func (p *packageGenerator) generateCodecHelpers(f *codegen.File) {
+	for _, wkt := range []WellKnown{WellKnownTimestamp, WellKnownDuration, WellKnownInt64Value, WellKnownUInt64Value} {
+		if _, ok := p.codecHelpers[decoderName(wkt)]; ok {
+			f.Write()
+			f.Write("/**")
+			f.Write(" * Decodes a ", wkt.Name(), " from its JSON representation.")
+			f.Write(" */")
+			f.Write("function ", decoderName(wkt), "(value: string): ", wkt.Name(), " {")
+			p.generateDecoderBody(f, wkt)
+			f.Write("}")
+		}
+		if _, ok := p.codecHelpers[encoderName(wkt)]; ok {
+			f.Write()
+			f.Write("/**")
+			f.Write(" * Encodes a ", wkt.Name(), " to its JSON representation.")
+			f.Write(" */")
+			f.Write("function ", encoderName(wkt), "(value: ", wkt.Name(), "): string {")
+			p.generateEncoderBody(f, wkt)
+			f.Write("}")
+		}
+	}
+}
+
+func (p *packageGenerator) generateDecoderBody(f *codegen.File, wkt WellKnown) {
+	switch {
+	case wkt == WellKnownTimestamp && p.options.timestamp == timestampAsDate:
+		f.Write(indentBy(1), "return new Date(value);")
+	case wkt == WellKnownTimestamp && p.options.timestamp == timestampAsTemporal:
+		f.Write(indentBy(1), "return Temporal.Instant.from(value);")
+	case wkt == WellKnownDuration && p.options.duration == durationAsMillis:
+		f.Write(indentBy(1), "return Number(value.slice(0, -1)) * 1000;")
+	case wkt == WellKnownDuration && p.options.duration == durationAsObject:
+		f.Write(indentBy(1), "const [seconds, fraction = \"\"] = value.slice(0, -1).split(\".\");")
+		f.Write(indentBy(1), "const nanos = Number(fraction.padEnd(9, \"0\").slice(0, 9));")
+		f.Write(indentBy(1), "return { seconds: Number(seconds), nanos: seconds.startsWith(\"-\") ? -nanos : nanos };")
+	case wkt == WellKnownInt64Value || wkt == WellKnownUInt64Value:
+		f.Write(indentBy(1), "return BigInt(value);")
+	}
+}
+
+func (p *packageGenerator) generateEncoderBody(f *codegen.File, wkt WellKnown) {
+	switch {
+	case wkt == WellKnownTimestamp && p.options.timestamp == timestampAsDate:
+		f.Write(indentBy(1), "return value.toISOString();")
+	case wkt == WellKnownTimestamp && p.options.timestamp == timestampAsTemporal:
+		f.Write(indentBy(1), "return value.toString();")
+	case wkt == WellKnownDuration && p.options.duration == durationAsMillis:
+		f.Write(indentBy(1), "return `${(value / 1000).toFixed(3)}s`;")
+	case wkt == WellKnownDuration && p.options.duration == durationAsObject:
+		f.Write(indentBy(1), "const sign = value.seconds < 0 || value.nanos < 0 ? \"-\" : \"\";")
+		f.Write(indentBy(1), "const nanos = Math.abs(value.nanos);")
+		f.Write(indentBy(1), "const fraction = nanos > 0 ? `.${String(nanos).padStart(9, \"0\")}` : \"\";")
+		f.Write(indentBy(1), "return `${sign}${Math.abs(value.seconds)}${fraction}s`;")
+	case wkt == WellKnownInt64Value || wkt == WellKnownUInt64Value:
+		f.Write(indentBy(1), "return String(value);")
+	}
+}
@@ -10,6 +10,7 @@ import (
 )
 
 type commentGenerator struct {
+	pkg        *packageGenerator
 	descriptor protoreflect.Descriptor
 }
 
@@ -26,13 +27,14 @@ func (c commentGenerator) generateLeading(f *codegen.File, indent int) {
 		commentLines = append(commentLines, strings.TrimSpace(line))
 	}
 
-	var behaviorComment string
+	var behaviorComment, defaultComment string
 	if field, ok := c.descriptor.(protoreflect.FieldDescriptor); ok {
 		behaviorComment = fieldBehaviorComment(field)
+		defaultComment = c.fieldDefaultComment(field)
 	}
 
-	// If there are no comments and no behaviors, do not write anything
-	if len(commentLines) == 0 && len(behaviorComment) == 0 {
+	// If there are no comments, no behaviors and no default, do not write anything
+	if len(commentLines) == 0 && len(behaviorComment) == 0 && len(defaultComment) == 0 {
 		return
 	}
 
@@ -46,9 +48,25 @@ func (c commentGenerator) generateLeading(f *codegen.File, indent int) {
 		}
 		f.Write(indentBy(indent), " * ", behaviorComment)
 	}
+	if len(defaultComment) > 0 {
+		if len(commentLines) > 0 && len(behaviorComment) == 0 {
+			f.Write(indentBy(indent), " * ")
+		}
+		f.Write(indentBy(indent), " * ", defaultComment)
+	}
 	f.Write(indentBy(indent), " */")
 }
 
+// fieldDefaultComment documents the explicit default value of a proto2 field,
+// which is the value of the field when it is not set, written as a value of the type of the field.
+// A "*/" in a string default would end the comment, so it is written as "*\/", the same string in TypeScript.
+func (c commentGenerator) fieldDefaultComment(field protoreflect.FieldDescriptor) string {
+	if !field.HasDefault() {
+		return ""
+	}
+	return "@default " + strings.ReplaceAll(c.pkg.defaultValue(field), "*/", `*\/`)
+}
+
 func fieldBehaviorComment(field protoreflect.FieldDescriptor) string {
 	behaviors := getFieldBehaviors(field)
 	if len(behaviors) == 0 {
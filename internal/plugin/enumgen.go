@@ -8,25 +8,122 @@ import (
 )
 
 type enumGenerator struct {
-	pkg  protoreflect.FullName
+	pkg  *packageGenerator
 	enum protoreflect.EnumDescriptor
 }
 
 func (e enumGenerator) Generate(f *codegen.File) {
-	commentGenerator{descriptor: e.enum}.generateLeading(f, 0)
-	f.Write("export type ", scopedDescriptorTypeName(e.pkg, e.enum), " =")
+	name := e.pkg.typeName(e.enum, defaultVariant)
+	commentGenerator{pkg: e.pkg, descriptor: e.enum}.generateLeading(f, 0)
+	f.Write("export type ", name, " =")
 	if e.enum.Values().Len() == 1 {
-		commentGenerator{descriptor: e.enum.Values().Get(0)}.generateLeading(f, 1)
+		commentGenerator{pkg: e.pkg, descriptor: e.enum.Values().Get(0)}.generateLeading(f, 1)
 		f.Write(indentBy(1), strconv.Quote(string(e.enum.Values().Get(0).Name())), ";")
-		return
+	} else {
+		rangeEnumValues(e.enum, func(value protoreflect.EnumValueDescriptor, last bool) {
+			commentGenerator{pkg: e.pkg, descriptor: value}.generateLeading(f, 1)
+			if last {
+				f.Write(indentBy(1), "| ", strconv.Quote(string(value.Name())), ";")
+			} else {
+				f.Write(indentBy(1), "| ", strconv.Quote(string(value.Name())))
+			}
+		})
 	}
-	rangeEnumValues(e.enum, func(value protoreflect.EnumValueDescriptor, last bool) {
-		commentGenerator{descriptor: value}.generateLeading(f, 1)
-		if last {
-			f.Write(indentBy(1), "| ", strconv.Quote(string(value.Name())), ";")
-		} else {
-			f.Write(indentBy(1), "| ", strconv.Quote(string(value.Name())))
+	f.Write()
+	if e.pkg.options.enumObjects {
+		e.generateObjects(f, name)
+	}
+	if e.pkg.options.enumObjects || e.pkg.options.numericEnums {
+		e.generateByNumber(f, name)
+	}
+	if e.pkg.options.enumObjects {
+		e.generateGuard(f, name)
+	}
+	if e.pkg.options.numericEnums {
+		e.generateNormalizer(f, name)
+	}
+}
+
+// generateObjects generates the runtime values of the enum, which share the name of its type.
+func (e enumGenerator) generateObjects(f *codegen.File, name string) {
+	f.Write("export const ", name, " = Object.freeze({")
+	rangeEnumValues(e.enum, func(value protoreflect.EnumValueDescriptor, _ bool) {
+		f.Write(indentBy(1), string(value.Name()), ": ", strconv.Quote(string(value.Name())), ",")
+	})
+	f.Write("} as const);")
+	f.Write()
+
+	f.Write("/**")
+	f.Write(" * All values of ", name, ", in declaration order.")
+	f.Write(" */")
+	f.Write("export const ", enumValuesName(name), ": readonly ", name, "[] = Object.freeze([")
+	rangeEnumValues(e.enum, func(value protoreflect.EnumValueDescriptor, _ bool) {
+		f.Write(indentBy(1), strconv.Quote(string(value.Name())), ",")
+	})
+	f.Write("]);")
+	f.Write()
+}
+
+// generateByNumber generates the map from the numbers of the enum to their names.
+func (e enumGenerator) generateByNumber(f *codegen.File, name string) {
+	// With allow_alias, several names share a number, and the first one is the canonical name
+	f.Write("/**")
+	f.Write(" * The names of ", name, " by number.")
+	f.Write(" */")
+	f.Write("export const ", enumByNumberName(name), ": Readonly<Record<number, ", name, ">> = Object.freeze({")
+	seen := make(map[protoreflect.EnumNumber]struct{})
+	rangeEnumValues(e.enum, func(value protoreflect.EnumValueDescriptor, _ bool) {
+		if _, ok := seen[value.Number()]; ok {
+			return
 		}
+		seen[value.Number()] = struct{}{}
+		f.Write(indentBy(1), strconv.Itoa(int(value.Number())), ": ", strconv.Quote(string(value.Name())), ",")
 	})
+	f.Write("});")
 	f.Write()
 }
+
+func (e enumGenerator) generateGuard(f *codegen.File, name string) {
+	f.Write("/**")
+	f.Write(" * Reports whether a value is a name of ", name, ".")
+	f.Write(" */")
+	f.Write("export function ", enumGuardName(name), "(value: unknown): value is ", name, " {")
+	f.Write(indentBy(1), "return typeof value === \"string\" && (", enumValuesName(name), " as readonly string[]).includes(value);")
+	f.Write("}")
+	f.Write()
+}
+
+// generateNormalizer generates the function converting a numeric value of the enum in a response to its name.
+// Parsers reject unknown numbers for closed enums, so their values always have a name.
+func (e enumGenerator) generateNormalizer(f *codegen.File, name string) {
+	f.Write("/**")
+	f.Write(" * Converts a numeric value of ", name, " to its name.")
+	if e.enum.IsClosed() {
+		f.Write(" */")
+		f.Write("export function ", enumNormalizerName(name), "(value: ", name, " | number): ", name, " {")
+		f.Write(indentBy(1), "return typeof value === \"number\" ? ", enumByNumberName(name), "[value] : value;")
+	} else {
+		f.Write(" * Numbers that are not known to this version of the enum are returned unchanged.")
+		f.Write(" */")
+		f.Write("export function ", enumNormalizerName(name), "(value: ", name, " | number): ", name, " | number {")
+		f.Write(indentBy(1), "return typeof value === \"number\" ? ", enumByNumberName(name), "[value] ?? value : value;")
+	}
+	f.Write("}")
+	f.Write()
+}
+
+func enumValuesName(name string) string {
+	return name + "Values"
+}
+
+func enumByNumberName(name string) string {
+	return name + "ByNumber"
+}
+
+func enumGuardName(name string) string {
+	return "is" + name
+}
+
+func enumNormalizerName(name string) string {
+	return "normalize" + name
+}
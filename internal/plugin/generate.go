@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
@@ -16,49 +17,195 @@ import (
 
 type generatorOptions struct {
 	verbose bool
+	int64   int64Mapping
+	// The error class thrown by generated clients when path variables are missing or invalid
+	pathError string
+	// The module to import pathError from, if it is not a global
+	pathErrorImport string
+	// The names of fields in the generated types, query parameters and request bodies
+	queryNames fieldNaming
+	// Whether enums are also generated as runtime objects
+	enumObjects bool
+	// Whether enums in responses may be numbers, see normalizeEnumsTransform
+	numericEnums bool
+	// Whether functions setting the default values of responses are generated, see withDefaultsTransform
+	withDefaults bool
+	// Whether all fields of responses are optional, since canonical JSON omits default values
+	strictResponses bool
+	// How map fields are written
+	mapType mapType
+	// Whether Any is typed as a union of the generated messages
+	typedAny bool
+	// How Timestamp and Duration values are represented by clients, see codec.go
+	timestamp timestampMapping
+	duration  durationMapping
+	// Whether methods without clients are generation errors rather than warnings
+	strict bool
 }
 
 func (o generatorOptions) String() string {
 	var opts []string
 	opts = append(opts, fmt.Sprintf("verbose=%v", o.verbose))
+	opts = append(opts, fmt.Sprintf("int64=%v", o.int64))
+	opts = append(opts, fmt.Sprintf("path_error=%v", o.pathError))
+	if o.pathErrorImport != "" {
+		opts = append(opts, fmt.Sprintf("path_error_import=%v", o.pathErrorImport))
+	}
+	opts = append(opts, fmt.Sprintf("query_names=%v", o.queryNames))
+	opts = append(opts, fmt.Sprintf("enum_objects=%v", o.enumObjects))
+	opts = append(opts, fmt.Sprintf("numeric_enums=%v", o.numericEnums))
+	opts = append(opts, fmt.Sprintf("with_defaults=%v", o.withDefaults))
+	opts = append(opts, fmt.Sprintf("strict_responses=%v", o.strictResponses))
+	opts = append(opts, fmt.Sprintf("map_type=%v", o.mapType))
+	opts = append(opts, fmt.Sprintf("typed_any=%v", o.typedAny))
+	opts = append(opts, fmt.Sprintf("timestamp=%v", o.timestamp))
+	opts = append(opts, fmt.Sprintf("duration=%v", o.duration))
+	opts = append(opts, fmt.Sprintf("strict=%v", o.strict))
 	return strings.Join(opts, ",")
 }
 
-var (
-	options          generatorOptions
-	generationErrors []error
+// fieldName returns the name of a field in the generated code.
+func (o generatorOptions) fieldName(field protoreflect.FieldDescriptor) string {
+	if o.queryNames == fieldNamingProto {
+		return string(field.Name())
+	}
+	return field.JSONName()
+}
+
+// int64Mapping is the TypeScript type used for 64-bit integer fields.
+type int64Mapping string
+
+const (
+	// int64AsString follows the canonical JSON mapping, where 64-bit integers are encoded as strings.
+	int64AsString int64Mapping = "string"
+	int64AsNumber int64Mapping = "number"
+	int64AsBigint int64Mapping = "bigint"
 )
 
+// fieldNaming selects the names of fields on the wire, which are also the names of fields in the
+// generated types, so that requests can be serialized without renaming their keys.
+type fieldNaming string
+
+const (
+	// fieldNamingJSON uses the lowerCamelCase JSON names of fields, as in canonical JSON.
+	fieldNamingJSON fieldNaming = "json"
+	// fieldNamingProto uses the original names of fields, as with preserve_proto_field_names.
+	fieldNamingProto fieldNaming = "proto"
+)
+
+// mapType is the TypeScript type used for map fields.
+type mapType string
+
+const (
+	// mapTypeIndex writes maps as objects with an index signature, such as { [key: string]: V }.
+	mapTypeIndex mapType = "index"
+	// mapTypeRecord writes maps as records, such as Record<string, V>.
+	mapTypeRecord mapType = "record"
+)
+
+// timestampMapping is the TypeScript type used for Timestamp values.
+type timestampMapping string
+
+const (
+	// timestampAsString follows the canonical JSON mapping, where timestamps are RFC 3339 strings.
+	timestampAsString   timestampMapping = "string"
+	timestampAsDate     timestampMapping = "date"
+	timestampAsTemporal timestampMapping = "temporal"
+)
+
+// durationMapping is the TypeScript type used for Duration values.
+type durationMapping string
+
+const (
+	// durationAsString follows the canonical JSON mapping, where durations are strings such as "1.5s".
+	durationAsString durationMapping = "string"
+	durationAsMillis durationMapping = "millis"
+	durationAsObject durationMapping = "object"
+)
+
+// usesCodec reports whether clients convert 64-bit integers, Timestamp or Duration values from and
+// to their JSON representation.
+func (o generatorOptions) usesCodec() bool {
+	return o.int64 == int64AsBigint || o.timestamp != timestampAsString || o.duration != durationAsString
+}
+
+// generator holds the state of a single invocation of Generate.
+type generator struct {
+	options  generatorOptions
+	errors   []error
+	warnings []string
+	// The variants that are generated for each message, see registerUsage
+	usage map[protoreflect.FullName]messageUsage
+	// The message variants that transforms are generated for, see registerConversions
+	conversions map[convertedVariant]bool
+}
+
 func log(args ...any) {
 	fmt.Fprint(os.Stderr, "protoc-gen-typescript-http: ")
 	fmt.Fprintln(os.Stderr, args...)
 }
 
 // logV is a verbose log function that only logs if verbose mode is enabled.
-func logV(args ...any) {
-	if !options.verbose {
+func (g *generator) logV(args ...any) {
+	if !g.options.verbose {
 		return
 	}
 	log(args...)
 }
 
-func addGenerationError(err error) {
-	generationErrors = append(generationErrors, err)
+// addGenerationError records an error in the input files, at the position of the offending
+// descriptor or option as returned by descriptorPosition.
+// Errors found more than once, such as by several bindings of a method, are recorded once.
+func (g *generator) addGenerationError(position string, err error) {
+	err = fmt.Errorf("%s: %w", position, err)
+	for _, recorded := range g.errors {
+		if recorded.Error() == err.Error() {
+			return
+		}
+	}
+	g.logV(err)
+	g.errors = append(g.errors, err)
+}
+
+// addWarning records a problem that does not fail the generation, which is logged even when not verbose.
+// Warnings found more than once, such as by several bindings of a method, are recorded once.
+func (g *generator) addWarning(warning string) {
+	if slices.Contains(g.warnings, warning) {
+		return
+	}
+	g.warnings = append(g.warnings, warning)
 }
 
 func Generate(request *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, error) {
+	res, warnings, err := generate(request)
+	if len(warnings) > 0 {
+		log("skipped methods and query parameters:")
+		for _, warning := range warnings {
+			log(warning)
+		}
+	}
+	return res, err
+}
+
+// generate generates the response to a request, together with the warnings about skipped methods
+// and query parameters, which do not fail the generation.
+func generate(request *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorResponse, []string, error) {
 	opts, err := parseOptions(request.GetParameter())
 	if err != nil {
-		return nil, fmt.Errorf("parse options: %w", err)
+		return &pluginpb.CodeGeneratorResponse{Error: proto.String(err.Error())}, nil, nil
 	}
 
-	options = opts
+	g := &generator{
+		options:     opts,
+		usage:       make(map[protoreflect.FullName]messageUsage),
+		conversions: make(map[convertedVariant]bool),
+	}
 
-	logV("options:", options)
+	g.logV("options:", g.options)
 
-	logV("generating files for", len(request.GetFileToGenerate()), "files")
+	g.logV("generating files for", len(request.GetFileToGenerate()), "files")
 	for _, f := range request.GetFileToGenerate() {
-		logV("generating file", f)
+		g.logV("generating file", f)
 	}
 
 	generate := make(map[string]struct{})
@@ -66,7 +213,7 @@ func Generate(request *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorRe
 		File: request.GetProtoFile(),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("create proto registry: %w", err)
+		return nil, nil, fmt.Errorf("create proto registry: %w", err)
 	}
 	for _, f := range request.GetFileToGenerate() {
 		generate[f] = struct{}{}
@@ -75,46 +222,92 @@ func Generate(request *pluginpb.CodeGeneratorRequest) (*pluginpb.CodeGeneratorRe
 	for _, f := range request.GetFileToGenerate() {
 		file, err := registry.FindFileByPath(f)
 		if err != nil {
-			return nil, fmt.Errorf("find file %s: %w", f, err)
+			return nil, nil, fmt.Errorf("find file %s: %w", f, err)
 		}
 		packageRegistry[file.Package()] = append(packageRegistry[file.Package()], file)
 	}
 
+	packages := make([]*packageGenerator, 0, len(packageRegistry))
+	for _, pkg := range sortedKeys(packageRegistry) {
+		p := newPackageGenerator(g, pkg, packageRegistry[pkg])
+		p.Register()
+		packages = append(packages, p)
+	}
+	g.registerUsage(packages)
+	g.registerConversions(packages)
+	if g.options.typedAny {
+		registerAnyMessages(packages)
+	}
+
 	var res pluginpb.CodeGeneratorResponse
-	for pkg, files := range packageRegistry {
-		logV(fmt.Sprint(string(pkg), ":"))
-		for _, file := range files {
-			logV(fmt.Sprint(indentBy(1), file.Path()))
+	for _, p := range packages {
+		g.logV(fmt.Sprint(string(p.name), ":"))
+		for _, file := range p.files {
+			g.logV(fmt.Sprint(indentBy(1), file.Path()))
 		}
 
 		var index codegen.File
-		indexPathElems := append(strings.Split(string(pkg), "."), "index.ts")
-		if err := (packageGenerator{pkg: pkg, files: files}).Generate(&index); err != nil {
-			return nil, fmt.Errorf("generate package '%s': %w", pkg, err)
-		}
+		p.Generate(&index)
 		index.Write()
 		index.Write("// @@protoc_insertion_point(typescript-http-eof)")
 		res.File = append(res.File, &pluginpb.CodeGeneratorResponse_File{
-			Name:    proto.String(path.Join(indexPathElems...)),
+			Name:    proto.String(packageFilePath(p.name)),
 			Content: proto.String(string(index.Content())),
 		})
 	}
-	res.SupportedFeatures = proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL))
+	res.SupportedFeatures = proto.Uint64(uint64(
+		pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS,
+	))
+	res.MinimumEdition = proto.Int32(int32(descriptorpb.Edition_EDITION_PROTO2))
+	res.MaximumEdition = proto.Int32(int32(descriptorpb.Edition_EDITION_2023))
 
-	if len(generationErrors) > 0 {
-		log("generation errors:")
-		for _, err := range generationErrors {
-			log(err)
+	// Errors in the input files are reported to protoc, which prints them and fails
+	if len(g.errors) > 0 {
+		messages := make([]string, 0, len(g.errors))
+		for _, err := range g.errors {
+			messages = append(messages, err.Error())
 		}
-		return nil, fmt.Errorf("encountered %d errors during generation", len(generationErrors))
+		res.File = nil
+		res.Error = proto.String(strings.Join(messages, "\n"))
 	}
 
-	return &res, nil
+	return &res, g.warnings, nil
+}
+
+// packageFilePath returns the path of the generated file for a package.
+func packageFilePath(pkg protoreflect.FullName) string {
+	return path.Join(append(strings.Split(string(pkg), "."), "index.ts")...)
+}
+
+// importPath returns the path to import the generated file of a package from the generated file of another package.
+func importPath(from, to protoreflect.FullName) string {
+	fromElems := strings.Split(string(from), ".")
+	toElems := strings.Split(string(to), ".")
+	common := 0
+	for common < len(fromElems) && common < len(toElems) && fromElems[common] == toElems[common] {
+		common++
+	}
+	elems := make([]string, 0, len(fromElems)-common+len(toElems)-common)
+	for i := common; i < len(fromElems); i++ {
+		elems = append(elems, "..")
+	}
+	elems = append(elems, toElems[common:]...)
+	if len(fromElems) == common {
+		return "./" + path.Join(elems...)
+	}
+	return path.Join(elems...)
 }
 
 // Looks like `jsdoc=true,verbose=true,param`
 func parseOptions(parameterString string) (generatorOptions, error) {
-	opts := generatorOptions{}
+	opts := generatorOptions{
+		int64:      int64AsString,
+		pathError:  "Error",
+		queryNames: fieldNamingJSON,
+		mapType:    mapTypeIndex,
+		timestamp:  timestampAsString,
+		duration:   durationAsString,
+	}
 	if parameterString == "" {
 		return opts, nil
 	}
@@ -132,9 +325,80 @@ func parseOptions(parameterString string) (generatorOptions, error) {
 		switch key {
 		case "verbose":
 			opts.verbose = val == "true"
+		case "int64":
+			switch m := int64Mapping(val); m {
+			case int64AsString, int64AsNumber, int64AsBigint:
+				opts.int64 = m
+			default:
+				return opts, fmt.Errorf("invalid value for option int64: %s", val)
+			}
+		case "path_error":
+			if !isIdentifier(val) {
+				return opts, fmt.Errorf("invalid value for option path_error: %s", val)
+			}
+			opts.pathError = val
+		case "path_error_import":
+			opts.pathErrorImport = val
+		case "enum_objects":
+			opts.enumObjects = val == "true"
+		case "numeric_enums":
+			opts.numericEnums = val == "true"
+		case "with_defaults":
+			opts.withDefaults = val == "true"
+		case "strict_responses":
+			opts.strictResponses = val == "true"
+		case "typed_any":
+			opts.typedAny = val == "true"
+		case "strict":
+			opts.strict = val == "true"
+		case "map_type":
+			switch m := mapType(val); m {
+			case mapTypeIndex, mapTypeRecord:
+				opts.mapType = m
+			default:
+				return opts, fmt.Errorf("invalid value for option map_type: %s", val)
+			}
+		case "timestamp":
+			switch m := timestampMapping(val); m {
+			case timestampAsString, timestampAsDate, timestampAsTemporal:
+				opts.timestamp = m
+			default:
+				return opts, fmt.Errorf("invalid value for option timestamp: %s", val)
+			}
+		case "duration":
+			switch m := durationMapping(val); m {
+			case durationAsString, durationAsMillis, durationAsObject:
+				opts.duration = m
+			default:
+				return opts, fmt.Errorf("invalid value for option duration: %s", val)
+			}
+		case "query_names":
+			switch n := fieldNaming(val); n {
+			case fieldNamingJSON, fieldNamingProto:
+				opts.queryNames = n
+			default:
+				return opts, fmt.Errorf("invalid value for option query_names: %s", val)
+			}
 		default:
 			return opts, fmt.Errorf("unknown option: %s", key)
 		}
 	}
 	return opts, nil
 }
+
+// isIdentifier reports whether s is a valid JavaScript identifier, restricted to ASCII.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$':
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
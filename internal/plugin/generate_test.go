@@ -0,0 +1,356 @@
+package plugin
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/evad1n/protoc-gen-typescript-http/internal/httprule"
+	// Registers google/type/latlng.proto, imported by the einride example.
+	_ "google.golang.org/genproto/googleapis/type/latlng"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/pluginpb"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/golden"
+)
+
+// Test_Generate_Golden generates the proto files of a directory in testdata, and compares the
+// generated files, or the generation error, with testdata/<dir>/<golden>.golden.
+// Update the golden files with -update.
+func Test_Generate_Golden(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		dir       string
+		golden    string
+		parameter string
+	}{
+		{dir: "packages", golden: "default"},
+		{dir: "variants", golden: "default"},
+		{dir: "bindings", golden: "default"},
+		{dir: "response_body", golden: "default"},
+		{dir: "response_body", golden: "numeric_enums", parameter: "numeric_enums=true"},
+		{dir: "bindings", golden: "path_error", parameter: "path_error=InvalidArgumentError,path_error_import=@example/errors"},
+		{dir: "encoding", golden: "default"},
+		{dir: "query", golden: "default"},
+		{dir: "query_encoding", golden: "default"},
+		{dir: "query_repeated", golden: "default"},
+		{dir: "query_skipped", golden: "default"},
+		{dir: "query", golden: "query_names", parameter: "query_names=proto"},
+		{dir: "query", golden: "invalid_query_names", parameter: "query_names=camel"},
+		{dir: "enums", golden: "default"},
+		{dir: "enums", golden: "enum_objects", parameter: "enum_objects=true"},
+		{dir: "enums", golden: "numeric_enums", parameter: "numeric_enums=true"},
+		{dir: "int64", golden: "default"},
+		{dir: "int64", golden: "number", parameter: "int64=number"},
+		{dir: "int64", golden: "bigint", parameter: "int64=bigint"},
+		{dir: "oneofs", golden: "default"},
+		{dir: "proto2", golden: "with_defaults", parameter: "with_defaults=true"},
+		{dir: "proto2", golden: "int64_bigint", parameter: "int64=bigint,with_defaults=true"},
+		{dir: "presence", golden: "default"},
+		{dir: "presence", golden: "strict_responses", parameter: "strict_responses=true"},
+		{dir: "maps", golden: "default"},
+		{dir: "maps", golden: "record", parameter: "map_type=record"},
+		{dir: "json", golden: "default"},
+		{dir: "any", golden: "default"},
+		{dir: "any", golden: "typed_any", parameter: "typed_any=true"},
+		{dir: "timestamps", golden: "date", parameter: "timestamp=date"},
+		{dir: "timestamps", golden: "temporal", parameter: "timestamp=temporal"},
+		{dir: "durations", golden: "millis", parameter: "duration=millis"},
+		{dir: "durations", golden: "object", parameter: "duration=object"},
+		{dir: "streaming", golden: "default"},
+		{dir: "streaming", golden: "timestamp", parameter: "timestamp=date"},
+		{dir: "client_streaming", golden: "default"},
+		{dir: "strict", golden: "default"},
+		{dir: "strict", golden: "strict", parameter: "strict=true"},
+		{dir: "positions", golden: "default"},
+		{dir: "rules", golden: "default"},
+	} {
+		t.Run(tt.dir+"/"+tt.golden, func(t *testing.T) {
+			t.Parallel()
+			res, warnings, err := generate(testdataRequest(t, tt.parameter, tt.dir))
+			assert.NilError(t, err)
+			golden.Assert(t, goldenContent(res, warnings...), filepath.Join(tt.dir, tt.golden+".golden"))
+		})
+	}
+}
+
+// goldenContent returns the generation error, the warnings and the generated files of a response
+// as a single text, with each part preceded by a "-- name --" line.
+func goldenContent(res *pluginpb.CodeGeneratorResponse, warnings ...string) string {
+	var b strings.Builder
+	if len(warnings) > 0 {
+		b.WriteString("-- warnings --\n")
+		b.WriteString(strings.Join(warnings, "\n"))
+		b.WriteString("\n")
+	}
+	if res.GetError() != "" {
+		b.WriteString("-- error --\n")
+		b.WriteString(res.GetError())
+		b.WriteString("\n")
+	}
+	for _, file := range res.GetFile() {
+		b.WriteString("-- " + file.GetName() + " --\n")
+		b.WriteString(file.GetContent())
+	}
+	return b.String()
+}
+
+// testdataRequest compiles every proto file of a directory in testdata into a request.
+func testdataRequest(t *testing.T, parameter, dir string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+	root := filepath.Join("testdata", dir)
+	var files []string
+	assert.NilError(t, filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".proto" {
+			return err
+		}
+		name, err := filepath.Rel(root, path)
+		files = append(files, filepath.ToSlash(name))
+		return err
+	}))
+	return compileRequest(t, parameter, root, files...)
+}
+
+// compileRequest compiles the proto files, relative to root, into a request as sent by protoc.
+// Imports that are not found in root are resolved from the files linked into the test.
+func compileRequest(t *testing.T, parameter, root string, files ...string) *pluginpb.CodeGeneratorRequest {
+	t.Helper()
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protocompile.CompositeResolver{
+			&protocompile.SourceResolver{ImportPaths: []string{root}},
+			protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+				file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+				return protocompile.SearchResult{Desc: file}, err
+			}),
+		}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	compiled, err := compiler.Compile(context.Background(), files...)
+	assert.NilError(t, err)
+	req := &pluginpb.CodeGeneratorRequest{
+		Parameter:      proto.String(parameter),
+		FileToGenerate: files,
+	}
+	// Files are sent in topological order, with each file after its imports.
+	added := make(map[string]bool)
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+		for i := 0; i < file.Imports().Len(); i++ {
+			add(file.Imports().Get(i).FileDescriptor)
+		}
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range compiled {
+		add(file)
+	}
+	// Options are compiled into dynamic messages, unmarshal them as the plugin receives them from protoc.
+	data, err := proto.Marshal(req)
+	assert.NilError(t, err)
+	var decoded pluginpb.CodeGeneratorRequest
+	assert.NilError(t, proto.Unmarshal(data, &decoded))
+	return &decoded
+}
+
+func Test_int64Type(t *testing.T) {
+	t.Parallel()
+	req := testdataRequest(t, "", "int64")
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: req.GetProtoFile()})
+	assert.NilError(t, err)
+	desc, err := files.FindDescriptorByName("example.int64.v1.Counter")
+	assert.NilError(t, err)
+	fields := desc.(protoreflect.MessageDescriptor).Fields()
+	for _, tt := range []struct {
+		int64    int64Mapping
+		field    protoreflect.Name
+		expected int64Mapping
+	}{
+		{int64: int64AsString, field: "count", expected: int64AsString},
+		{int64: int64AsBigint, field: "count", expected: int64AsBigint},
+		{int64: int64AsNumber, field: "total", expected: int64AsNumber},
+		// A jstype option takes precedence over the int64 option
+		{int64: int64AsBigint, field: "as_string", expected: int64AsString},
+		{int64: int64AsBigint, field: "as_number", expected: int64AsNumber},
+		{int64: int64AsNumber, field: "as_string", expected: int64AsString},
+		{int64: int64AsString, field: "as_number", expected: int64AsNumber},
+	} {
+		t.Run(string(tt.int64)+"/"+string(tt.field), func(t *testing.T) {
+			t.Parallel()
+			opts := generatorOptions{int64: tt.int64}
+			assert.Equal(t, opts.int64Type(fields.ByName(tt.field)), tt.expected)
+		})
+	}
+}
+
+func Test_Generate_PackageVariants(t *testing.T) {
+	t.Parallel()
+	// The types declared by a package do not depend on the other packages generated with it,
+	// so that packages can be generated in separate invocations.
+	root := filepath.Join("testdata", "variants")
+	alone, err := Generate(compileRequest(t, "", root, "common.proto"))
+	assert.NilError(t, err)
+	together, err := Generate(compileRequest(t, "", root, "common.proto", "freight.proto"))
+	assert.NilError(t, err)
+	assert.Equal(t, alone.GetFile()[0].GetName(), together.GetFile()[0].GetName())
+	assert.Equal(t, alone.GetFile()[0].GetContent(), together.GetFile()[0].GetContent())
+}
+
+func Test_Generate_Deterministic(t *testing.T) {
+	t.Parallel()
+	req := testdataRequest(t, "", "packages")
+	first, err := Generate(req)
+	assert.NilError(t, err)
+	for i := 0; i < 10; i++ {
+		next, err := Generate(req)
+		assert.NilError(t, err)
+		assert.Equal(t, goldenContent(first), goldenContent(next))
+	}
+}
+
+func Test_Generate_Concurrent(t *testing.T) {
+	t.Parallel()
+	req := testdataRequest(t, "", "packages")
+	want, err := Generate(req)
+	assert.NilError(t, err)
+	var wg sync.WaitGroup
+	results := make([]*pluginpb.CodeGeneratorResponse, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = Generate(req)
+		}(i)
+	}
+	wg.Wait()
+	for _, got := range results {
+		assert.Assert(t, got != nil)
+		assert.Equal(t, goldenContent(want), goldenContent(got))
+	}
+}
+
+func Test_importPath(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		from, to protoreflect.FullName
+		expected string
+	}{
+		{from: "a.b.c", to: "a.b.d", expected: "../d"},
+		{from: "a.b.c", to: "a.d.e", expected: "../../d/e"},
+		{from: "a.b", to: "a.b.c", expected: "./c"},
+		{from: "a.b.c", to: "a.b", expected: ".."},
+		{from: "a", to: "b", expected: "../b"},
+	} {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, importPath(tt.from, tt.to))
+		})
+	}
+}
+
+func Test_variablePattern(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		template string
+		expected string
+	}{
+		{template: "/{name}", expected: `[^\/]+`},
+		{template: "/{name=shippers/*}", expected: `shippers\/[^\/]+`},
+		{template: "/{name=orgs/*/shippers/*}", expected: `orgs\/[^\/]+\/shippers\/[^\/]+`},
+		{template: "/{name=files/**}", expected: `files\/.*`},
+		{template: "/{name=v1.0/*}", expected: `v1\.0\/[^\/]+`},
+	} {
+		t.Run(tt.template, func(t *testing.T) {
+			t.Parallel()
+			template, err := httprule.ParseTemplate(tt.template)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, variablePattern(template.Segments[0].Variable.Segments))
+		})
+	}
+}
+
+func Test_Generate_Editions(t *testing.T) {
+	t.Parallel()
+	req := compileRequest(t, "", filepath.Join(examplesDir(t), "editions", "proto"), "editions/v1/library.proto")
+	res, err := Generate(req)
+	assert.NilError(t, err)
+	assert.Equal(t, res.GetSupportedFeatures()&uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS), uint64(pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS))
+	assert.Equal(t, res.GetMaximumEdition(), int32(descriptorpb.Edition_EDITION_2023))
+}
+
+// Test_Generate_Examples checks the generated code of the examples, update it with -update.
+// The options and inputs of each example must match its buf.gen.yaml.
+func Test_Generate_Examples(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name      string
+		parameter string
+		files     []string
+	}{
+		{
+			name: "einride",
+			files: []string{
+				"proto/freight/v1/freight_service.proto",
+				"proto/freight/v1/shipment.proto",
+				"proto/freight/v1/shipper.proto",
+				"proto/freight/v1/site.proto",
+				"proto/syntax/v1/syntax.proto",
+				"proto/syntax/v1/syntax_service.proto",
+				"proto/syntax/v2/forward.proto",
+				"google/type/latlng.proto",
+			},
+		},
+		{
+			name:      "simple",
+			parameter: "verbose=true",
+			files:     []string{"api/element.proto", "api/user.proto"},
+		},
+		{
+			name:      "editions",
+			parameter: "numeric_enums=true",
+			files:     []string{"proto/editions/v1/library.proto"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := filepath.Join(examplesDir(t), tt.name)
+			res, err := Generate(compileRequest(t, tt.parameter, dir, tt.files...))
+			assert.NilError(t, err)
+			assert.Equal(t, res.GetError(), "")
+			for _, file := range res.GetFile() {
+				golden.Assert(t, file.GetContent(), filepath.Join(dir, "gen", file.GetName()))
+			}
+		})
+	}
+}
+
+// examplesDir returns the absolute path of the examples directory.
+func examplesDir(t *testing.T) string {
+	t.Helper()
+	dir, err := filepath.Abs(filepath.Join("..", "..", "examples"))
+	assert.NilError(t, err)
+	return dir
+}
+
+func Test_descriptorPosition(t *testing.T) {
+	t.Parallel()
+	req := testdataRequest(t, "", "strict")
+	files, err := protodesc.NewFiles(&descriptorpb.FileDescriptorSet{File: req.GetProtoFile()})
+	assert.NilError(t, err)
+	method, err := files.FindDescriptorByName("example.admin.v1.AdminService.Purge")
+	assert.NilError(t, err)
+	assert.Equal(t, descriptorPosition(method), "admin.proto:8:3")
+	// Descriptors without source information have the position of their file
+	assert.Equal(t, descriptorPosition((&timestamppb.Timestamp{}).ProtoReflect().Descriptor()), "google/protobuf/timestamp.proto")
+}
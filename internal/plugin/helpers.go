@@ -1,31 +1,47 @@
 package plugin
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
+	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-func scopedDescriptorTypeName(pkg protoreflect.FullName, desc protoreflect.Descriptor) string {
+func descriptorTypeName(desc protoreflect.Descriptor) string {
 	name := string(desc.Name())
 	var prefix string
 	if desc.Parent() != desc.ParentFile() {
 		prefix = descriptorTypeName(desc.Parent()) + "_"
 	}
-	if desc.ParentFile().Package() != pkg {
-		prefix = packagePrefix(desc.ParentFile().Package()) + prefix
-	}
 	return prefix + name
 }
 
-func descriptorTypeName(desc protoreflect.Descriptor) string {
-	name := string(desc.Name())
-	var prefix string
-	if desc.Parent() != desc.ParentFile() {
-		prefix = descriptorTypeName(desc.Parent()) + "_"
+// descriptorPosition returns the position of a descriptor in its file as "file.proto:line:column",
+// or only the path of the file when it has no source information.
+func descriptorPosition(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(desc)
+	if loc.Path == nil {
+		return file.Path()
 	}
-	return prefix + name
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
+
+// httpRulePosition returns the position of the google.api.http option of a method,
+// or the position of the method when the option has no source information.
+func httpRulePosition(method protoreflect.MethodDescriptor) string {
+	const methodOptionsFieldNumber = 4 // google.protobuf.MethodDescriptorProto.options
+	file := method.ParentFile()
+	if loc := file.SourceLocations().ByDescriptor(method); loc.Path != nil {
+		path := append(slices.Clone(loc.Path), methodOptionsFieldNumber, int32(annotations.E_Http.TypeDescriptor().Number()))
+		if loc := file.SourceLocations().ByPath(path); loc.Path != nil {
+			return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+		}
+	}
+	return descriptorPosition(method)
 }
 
 func packagePrefix(pkg protoreflect.FullName) string {
@@ -72,3 +88,28 @@ func suffixName(name string, suffix string) string {
 
 	return fmt.Sprintf("%s%s", name, suffix)
 }
+
+// sortedKeys returns the keys of a map in sorted order, so that generated output is stable between runs.
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// sortedDescriptors returns the descriptor keys of a map sorted by full name, so that generated output is stable between runs.
+func sortedDescriptors[D interface {
+	comparable
+	protoreflect.Descriptor
+}, V any](m map[D]V) []D {
+	descs := make([]D, 0, len(m))
+	for d := range m {
+		descs = append(descs, d)
+	}
+	slices.SortFunc(descs, func(a, b D) int {
+		return cmp.Compare(a.FullName(), b.FullName())
+	})
+	return descs
+}
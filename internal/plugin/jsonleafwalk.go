@@ -34,8 +34,9 @@ func (w *jsonWalker) walkMessage(path httprule.FieldPath, message protoreflect.M
 			p := append(httprule.FieldPath{}, path...)
 			p = append(p, string(field.Name()))
 			switch {
-			case !field.IsMap() && field.Kind() == protoreflect.MessageKind:
-				if IsWellKnownType(field.Message()) {
+			case !field.IsMap() && field.Message() != nil:
+				// Repeated messages are leaves, since their fields do not have a path of their own
+				if IsWellKnownType(field.Message()) || field.IsList() {
 					f(p, field)
 				} else {
 					w.walkMessage(p, field.Message(), f)
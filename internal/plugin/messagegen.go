@@ -1,7 +1,6 @@
 package plugin
 
 import (
-	"fmt"
 	"slices"
 
 	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
@@ -9,169 +8,123 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-var (
-	generatedMessagesRegistry = make(map[string]protoreflect.MessageDescriptor) // To avoid generating the same message multiple times
-)
-
 type messageGenerator struct {
-	pkg            protoreflect.FullName
-	message        protoreflect.MessageDescriptor
-	usedInRequest  bool
-	usedInResponse bool
+	pkg     *packageGenerator
+	message protoreflect.MessageDescriptor
+	messageUsage
 }
 
 func (m messageGenerator) Generate(f *codegen.File) {
-	if !m.usedInRequest && !m.usedInResponse {
-		m.generateDefaultType(f)
-	}
-
-	if m.usedInRequest {
-		m.generateRequestType(f)
+	for _, variant := range []messageVariant{defaultVariant, requestVariant, responseVariant} {
+		if m.has(variant) {
+			m.generateType(f, variant)
+		}
 	}
-
-	if m.usedInResponse {
-		m.generateResponseType(f)
+	for _, t := range m.pkg.transforms() {
+		for _, variant := range t.variants(m.pkg, m.message) {
+			if m.has(variant) && m.pkg.converts(m.message, variant, t.variant) && t.applies(m.pkg, m.message, variant) {
+				t.generate(f, m.pkg, m.message, variant)
+			}
+		}
 	}
 }
 
-func (m messageGenerator) generateDefaultType(f *codegen.File) {
-	commentGenerator{descriptor: m.message}.generateLeading(f, 0)
-
-	f.Write("export type ", scopedDescriptorTypeName(m.pkg, m.message), " = {")
-
-	rangeFields(m.message, func(field protoreflect.FieldDescriptor) {
-		commentGenerator{descriptor: field}.generateLeading(f, 1)
-		fieldType := typeFromField(m.pkg, field)
-
-		f.Write(indentBy(1), field.JSONName(), ": ", fieldType.Reference(), ";")
-	})
-
-	f.Write("};")
+func (m messageGenerator) generateType(f *codegen.File, variant messageVariant) {
+	commentGenerator{pkg: m.pkg, descriptor: m.message}.generateLeading(f, 0)
+
+	f.Write("export type ", m.pkg.typeName(m.message, variant), " = {")
+
+	m.generateFields(
+		f,
+		variant,
+		func(field protoreflect.FieldDescriptor) bool {
+			return isFieldGenerated(field, variant)
+		},
+		func(field protoreflect.FieldDescriptor) (string, string) {
+			symbol := getFieldCardinalitySymbol(field, variant, m.pkg.options.strictResponses)
+			return symbol, m.pkg.typeFromField(field, variant).Reference()
+		},
+	)
 	f.Write()
 }
 
-func (m messageGenerator) generateRequestType(f *codegen.File) {
-	commentGenerator{descriptor: m.message}.generateLeading(f, 0)
-
-	// We are generating inline, so keep track of addintional messages that need to be generated and generate at the end
-	additionalMessagesToGenerate := make(map[protoreflect.FullName]messageGenerator)
-
-	typeName := suffixName(scopedDescriptorTypeName(m.pkg, m.message), REQUEST_SUFFIX)
-	if existingType, ok := generatedMessagesRegistry[typeName]; ok {
-		// If the request type already exists, we can skip generating it.
-		log(fmt.Sprintf("skipping request type %s, already exists as %s", typeName, existingType))
-		return
-	}
-	generatedMessagesRegistry[typeName] = m.message
-
-	f.Write("export type ", typeName, " = {")
-
+// generateFields writes the fields of the message, starting after the opening brace of the type.
+// Fields that are part of a oneof are written as an exclusive union after the regular fields,
+// where each alternative has exactly one member present and the other members typed as never.
+// A last alternative with every member typed as never stands for a oneof without a member set,
+// unless the variant is a request and a member is REQUIRED.
+// The include function decides which fields are part of the type, and the declare function
+// returns the cardinality symbol and type name of a field.
+func (m messageGenerator) generateFields(
+	f *codegen.File,
+	variant messageVariant,
+	include func(field protoreflect.FieldDescriptor) bool,
+	declare func(field protoreflect.FieldDescriptor) (string, string),
+) {
+	var oneofs []protoreflect.OneofDescriptor
+	oneofFields := make(map[protoreflect.FullName][]protoreflect.FieldDescriptor)
 	rangeFields(m.message, func(field protoreflect.FieldDescriptor) {
-		if !getFieldShouldGenerate(field, true) {
+		if !include(field) {
 			return
 		}
-
-		commentGenerator{descriptor: field}.generateLeading(f, 1)
-
-		var fieldTypeName string
-
-		if field.Kind() == protoreflect.MessageKind {
-			message := field.Message()
-
-			messageRequiresDiscrimination := getMessageRequiresDiscrimination(message, 0, make(map[protoreflect.FullName]bool))
-
-			if messageRequiresDiscrimination {
-				fieldTypeName = suffixName(scopedDescriptorTypeName(m.pkg, message), REQUEST_SUFFIX)
-
-				// If the field is a message we need to recurse
-				messageGen := messageGenerator{
-					pkg:            m.pkg,
-					message:        message,
-					usedInRequest:  true,
-					usedInResponse: m.usedInResponse,
-				}
-				additionalMessagesToGenerate[message.FullName()] = messageGen
-			} else {
-				fieldTypeName = typeFromField(m.pkg, field).Reference()
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			if _, ok := oneofFields[oneof.FullName()]; !ok {
+				oneofs = append(oneofs, oneof)
 			}
-		} else {
-			fieldTypeName = typeFromField(m.pkg, field).Reference()
+			oneofFields[oneof.FullName()] = append(oneofFields[oneof.FullName()], field)
+			return
 		}
-
-		fieldCardinalitySymbol := getFieldCardinalitySymbol(field, true)
-
-		f.Write(indentBy(1), field.JSONName(), fieldCardinalitySymbol, ": ", fieldTypeName, ";")
+		commentGenerator{pkg: m.pkg, descriptor: field}.generateLeading(f, 1)
+		symbol, typeName := declare(field)
+		f.Write(indentBy(1), m.pkg.options.fieldName(field), symbol, ": ", typeName, ";")
 	})
 
-	f.Write("};")
-	f.Write()
-
-	// Generate additional messages that were collected
-	for _, additionalMessage := range additionalMessagesToGenerate {
-		additionalMessage.generateRequestType(f)
-	}
-}
-
-func (m messageGenerator) generateResponseType(f *codegen.File) {
-	commentGenerator{descriptor: m.message}.generateLeading(f, 0)
-
-	// We are generating inline, so keep track of addintional messages that need to be generated and generate at the end
-	additionalMessagesToGenerate := make(map[protoreflect.FullName]messageGenerator)
-
-	typeName := suffixName(scopedDescriptorTypeName(m.pkg, m.message), RESPONSE_SUFFIX)
-	if existingType, ok := generatedMessagesRegistry[typeName]; ok {
-		// If the response type already exists, we can skip generating it.
-		log(fmt.Sprintf("skipping response type %s, already exists as %s", typeName, existingType))
+	if len(oneofs) == 0 {
+		f.Write("};")
 		return
 	}
-	generatedMessagesRegistry[typeName] = m.message
 
-	f.Write("export type ", typeName, " = {")
-
-	rangeFields(m.message, func(field protoreflect.FieldDescriptor) {
-		if !getFieldShouldGenerate(field, false) {
-			return
+	f.Write("} & (")
+	for i, oneof := range oneofs {
+		if i > 0 {
+			f.Write(") & (")
 		}
-
-		commentGenerator{descriptor: field}.generateLeading(f, 1)
-
-		var fieldTypeName string
-
-		if field.Kind() == protoreflect.MessageKind {
-			message := field.Message()
-
-			messageRequiresDiscrimination := getMessageRequiresDiscrimination(message, 0, make(map[protoreflect.FullName]bool))
-
-			if messageRequiresDiscrimination {
-				fieldTypeName = suffixName(scopedDescriptorTypeName(m.pkg, message), RESPONSE_SUFFIX)
-
-				// If the field is a message we need to recurse
-				messageGen := messageGenerator{
-					pkg:            m.pkg,
-					message:        message,
-					usedInRequest:  m.usedInRequest,
-					usedInResponse: true,
+		commentGenerator{pkg: m.pkg, descriptor: oneof}.generateLeading(f, 1)
+		fields := oneofFields[oneof.FullName()]
+		for _, present := range fields {
+			f.Write(indentBy(1), "| {")
+			for _, field := range fields {
+				if field != present {
+					f.Write(indentBy(2), m.pkg.options.fieldName(field), "?: never;")
+					continue
 				}
-				additionalMessagesToGenerate[message.FullName()] = messageGen
-			} else {
-				fieldTypeName = typeFromField(m.pkg, field).Reference()
+				commentGenerator{pkg: m.pkg, descriptor: field}.generateLeading(f, 2)
+				_, typeName := declare(field)
+				f.Write(indentBy(2), m.pkg.options.fieldName(field), ": ", typeName, ";")
 			}
-		} else {
-			fieldTypeName = typeFromField(m.pkg, field).Reference()
+			f.Write(indentBy(1), "}")
 		}
+		if variant == requestVariant && slices.ContainsFunc(fields, isRequiredField) {
+			continue
+		}
+		f.Write(indentBy(1), "| {")
+		for _, field := range fields {
+			f.Write(indentBy(2), m.pkg.options.fieldName(field), "?: never;")
+		}
+		f.Write(indentBy(1), "}")
+	}
+	f.Write(");")
+}
 
-		fieldCardinalitySymbol := getFieldCardinalitySymbol(field, false)
-
-		f.Write(indentBy(1), field.JSONName(), fieldCardinalitySymbol, ": ", fieldTypeName, ";")
-	})
-
-	f.Write("};")
-	f.Write()
+// isRequiredField reports whether a field has the REQUIRED field behavior.
+func isRequiredField(field protoreflect.FieldDescriptor) bool {
+	return slices.Contains(getFieldBehaviors(field), annotations.FieldBehavior_REQUIRED)
+}
 
-	// Generate additional messages that were collected
-	for _, additionalMessage := range additionalMessagesToGenerate {
-		additionalMessage.generateResponseType(f)
-	}
+// isFieldGenerated reports whether a field is part of a message variant. The default variant has
+// every field, while OUTPUT_ONLY fields are left out of requests and INPUT_ONLY fields of responses.
+func isFieldGenerated(field protoreflect.FieldDescriptor, variant messageVariant) bool {
+	return variant == defaultVariant || getFieldShouldGenerate(field, variant == requestVariant)
 }
 
 func getFieldShouldGenerate(field protoreflect.FieldDescriptor, isRequest bool) bool {
@@ -193,22 +146,47 @@ func getFieldShouldGenerate(field protoreflect.FieldDescriptor, isRequest bool)
 	return true
 }
 
-func getFieldCardinalitySymbol(field protoreflect.FieldDescriptor, isRequest bool) string {
+// getFieldCardinalitySymbol returns "?" for fields that may be absent from a message variant.
+// The default variant is read from responses as well, so it follows the rules of responses.
+func getFieldCardinalitySymbol(field protoreflect.FieldDescriptor, variant messageVariant, strictResponses bool) string {
 	behaviors := getFieldBehaviors(field)
 
-	if field.ContainingOneof() != nil {
-		return "?"
+	// proto2 required fields, and fields with the field_presence feature set to LEGACY_REQUIRED, are always set.
+	if field.Cardinality() == protoreflect.Required {
+		return ""
 	}
 
-	if isRequest {
+	if variant == requestVariant {
+		// Fields with explicit presence, such as proto3 optional fields or fields with the field_presence
+		// feature set to EXPLICIT, may be absent. Presence is resolved from the features of the field,
+		// and singular message fields are excluded since they always have presence.
+		// Members of real oneofs are written by generateFields and never reach this.
+		// Fields that are REQUIRED must be set in requests, regardless of their presence.
+		if hasExplicitPresence(field) && !slices.Contains(behaviors, annotations.FieldBehavior_REQUIRED) {
+			return "?"
+		}
 		if slices.Contains(behaviors, annotations.FieldBehavior_OPTIONAL) {
 			return "?"
 		}
+		return ""
+	}
+
+	// Canonical JSON omits fields with presence when they are not set, including message fields.
+	// With strict_responses, fields without presence are optional as well, since canonical JSON
+	// also omits them when they have their default value.
+	if field.HasPresence() || strictResponses {
+		return "?"
 	}
 
 	return ""
 }
 
+// hasExplicitPresence reports whether a scalar or enum field distinguishes between being unset and
+// having its default value.
+func hasExplicitPresence(field protoreflect.FieldDescriptor) bool {
+	return field.HasPresence() && field.Message() == nil
+}
+
 var behaviorsRequiringDiscrimination = []annotations.FieldBehavior{
 	annotations.FieldBehavior_OUTPUT_ONLY,
 	annotations.FieldBehavior_INPUT_ONLY,
@@ -226,7 +204,7 @@ func getMessageRequiresDiscrimination(message protoreflect.MessageDescriptor, de
 	messageRequiresDiscrimination := false
 	rangeFields(message, func(field protoreflect.FieldDescriptor) {
 		// If it's a message field, we need to check its nested fields as well.
-		if field.Kind() == protoreflect.MessageKind {
+		if field.Message() != nil {
 			nestedMessage := field.Message()
 
 			if getMessageRequiresDiscrimination(nestedMessage, depth+1, visited) {
@@ -1,46 +1,109 @@
 package plugin
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
 	"github.com/evad1n/protoc-gen-typescript-http/internal/protowalk"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-type messageEntry struct {
-	message        protoreflect.MessageDescriptor
-	usedInRequest  bool
-	usedInResponse bool
-}
-
 type serviceEntry struct {
 	service protoreflect.ServiceDescriptor
 }
 
-var (
-	messageRegistry       = make(map[protoreflect.FullName]messageEntry)
-	serviceRegistry       = make(map[protoreflect.ServiceDescriptor]serviceEntry)
-	enumRegistry          = make(map[protoreflect.EnumDescriptor]protoreflect.EnumDescriptor)
-	wellKnownTypeRegistry = make(map[WellKnown]WellKnown)
-)
-
+// packageGenerator generates the index file of a single proto package.
+// It holds the registries of everything the package declares or references.
 type packageGenerator struct {
-	pkg   protoreflect.FullName
+	*generator
+
+	name  protoreflect.FullName
 	files []protoreflect.FileDescriptor
+
+	messageRegistry       map[protoreflect.FullName]protoreflect.MessageDescriptor
+	serviceRegistry       map[protoreflect.ServiceDescriptor]serviceEntry
+	enumRegistry          map[protoreflect.EnumDescriptor]protoreflect.EnumDescriptor
+	wellKnownTypeRegistry map[WellKnown]WellKnown
+
+	// Names declared by the file, which imported names must not collide with
+	declaredNames map[string]struct{}
+	// Imported names, by package and then by exported name, mapped to their local name
+	imports map[protoreflect.FullName]map[string]string
+	// Imported functions and constants, in the same form as imports
+	valueImports map[protoreflect.FullName]map[string]string
+	// Local names of all imported types
+	importedNames map[string]struct{}
+	// The functions used by clients to encode path variables, see GeneratePathEncoders
+	pathEncoders map[string]struct{}
+	// The functions used to convert well known types, see generateCodecHelpers
+	codecHelpers map[string]struct{}
+	// Whether clients call server streaming methods, see GenerateStreamResults
+	streaming bool
+	// The messages that a typed Any may contain, see registerAnyMessages
+	anyMessages []protoreflect.MessageDescriptor
 }
 
-func (p packageGenerator) Generate(f *codegen.File) error {
-	p.Register()
-	if err := p.generateFromRegistry(f); err != nil {
-		return err
+func newPackageGenerator(g *generator, name protoreflect.FullName, files []protoreflect.FileDescriptor) *packageGenerator {
+	return &packageGenerator{
+		generator:             g,
+		name:                  name,
+		files:                 files,
+		messageRegistry:       make(map[protoreflect.FullName]protoreflect.MessageDescriptor),
+		serviceRegistry:       make(map[protoreflect.ServiceDescriptor]serviceEntry),
+		enumRegistry:          make(map[protoreflect.EnumDescriptor]protoreflect.EnumDescriptor),
+		wellKnownTypeRegistry: make(map[WellKnown]WellKnown),
+		declaredNames: map[string]struct{}{
+			"RequestType":                   {},
+			"RequestHandler":                {},
+			"encodePathSegment":             {},
+			"encodePathSegments":            {},
+			g.options.pathError:             {},
+			"packAny":                       {},
+			"unpackAny":                     {},
+			decoderName(WellKnownTimestamp): {},
+			encoderName(WellKnownTimestamp): {},
+			decoderName(WellKnownDuration):  {},
+			encoderName(WellKnownDuration):  {},
+		},
+		imports:       make(map[protoreflect.FullName]map[string]string),
+		valueImports:  make(map[protoreflect.FullName]map[string]string),
+		importedNames: make(map[string]struct{}),
+		pathEncoders:  make(map[string]struct{}),
+		codecHelpers:  make(map[string]struct{}),
 	}
+}
 
-	return nil
+func (p *packageGenerator) Generate(f *codegen.File) {
+	// The body is generated first, since it determines the imports and well known types of the file
+	var body codegen.File
+	p.generateFromRegistry(&body)
+
+	// With typed_any, Any references the messages of the package and its imports, which may need importing
+	var typedAny codegen.File
+	_, hasAny := p.wellKnownTypeRegistry[WellKnownAny]
+	if hasAny && p.options.typedAny {
+		p.generateTypedAny(&typedAny)
+	}
+
+	GeneratePackageHeader(f)
+	p.generateImports(f)
+	for _, t := range sortedKeys(p.wellKnownTypeRegistry) {
+		if t == WellKnownAny && p.options.typedAny {
+			f.Append(&typedAny)
+			continue
+		}
+		f.Write(t.TypeDeclaration(p.options))
+	}
+	f.Append(&body)
 }
 
-func (p packageGenerator) Register() {
+// Register registers the messages, enums and services declared by the package.
+// Types from other packages are imported from the file generated for their package.
+func (p *packageGenerator) Register() {
 	protowalk.WalkFiles(p.files, func(desc protoreflect.Descriptor) bool {
-		if wkt, ok := WellKnownType(desc); ok {
-			wellKnownTypeRegistry[wkt] = wkt
+		if desc.ParentFile().Package() != p.name || IsWellKnownType(desc) {
 			return false
 		}
 		switch v := desc.(type) {
@@ -48,111 +111,163 @@ func (p packageGenerator) Register() {
 			if v.IsMapEntry() {
 				return false
 			}
-			messageRegistry[v.FullName()] = messageEntry{message: v}
+			p.messageRegistry[v.FullName()] = v
+			p.checkFieldNames(v)
+			name := descriptorTypeName(v)
+			p.declaredNames[name] = struct{}{}
+			p.declaredNames[suffixName(name, REQUEST_SUFFIX)] = struct{}{}
+			p.declaredNames[suffixName(name, RESPONSE_SUFFIX)] = struct{}{}
+			for _, t := range p.transforms() {
+				p.declaredNames[t.functionName(name)] = struct{}{}
+				p.declaredNames[t.functionName(suffixName(name, t.variant.suffix()))] = struct{}{}
+			}
 		case protoreflect.EnumDescriptor:
-			enumRegistry[v] = v
+			p.enumRegistry[v] = v
+			name := descriptorTypeName(v)
+			p.declaredNames[name] = struct{}{}
+			if p.options.enumObjects {
+				p.declaredNames[enumValuesName(name)] = struct{}{}
+				p.declaredNames[enumGuardName(name)] = struct{}{}
+			}
+			if p.options.enumObjects || p.options.numericEnums {
+				p.declaredNames[enumByNumberName(name)] = struct{}{}
+			}
+			if p.options.numericEnums {
+				p.declaredNames[enumNormalizerName(name)] = struct{}{}
+			}
 		case protoreflect.ServiceDescriptor:
-			serviceRegistry[v] = serviceEntry{service: v}
+			p.serviceRegistry[v] = serviceEntry{service: v}
+			p.declaredNames[descriptorTypeName(v)] = struct{}{}
+			p.declaredNames["create"+descriptorTypeName(v)+"Client"] = struct{}{}
 		}
 		return true
 	})
 
-	if options.verbose {
-		log("Registered package:", p.pkg)
-		log("Messages registered:", len(messageRegistry))
-		log("Services registered:", len(serviceRegistry))
-		log("Enums registered:", len(enumRegistry))
-		log("Well known types registered:", len(wellKnownTypeRegistry))
-	}
-
-	// Second pass on the services to determine what messages are used for the request/response
-	for serviceDescriptor := range serviceRegistry {
-		methods := serviceDescriptor.Methods()
-		for i := 0; i < methods.Len(); i++ {
-			method := methods.Get(i)
-
-			inputMessageName := method.Input().FullName()
-			inputMessageEntry, ok := messageRegistry[inputMessageName]
-			if !ok {
-				log("Warning: input message", inputMessageName, "not found in registry for service", serviceDescriptor.FullName())
-			} else {
-				messageRegistry[inputMessageName] = messageEntry{
-					message:        inputMessageEntry.message,
-					usedInRequest:  true,
-					usedInResponse: inputMessageEntry.usedInResponse,
-				}
-			}
+	if p.options.verbose {
+		log("Registered package:", p.name)
+		log("Messages registered:", len(p.messageRegistry))
+		log("Services registered:", len(p.serviceRegistry))
+		log("Enums registered:", len(p.enumRegistry))
+	}
+}
 
-			outputMessageName := method.Output().FullName()
-			outputMessageEntry, ok := messageRegistry[outputMessageName]
-			if !ok {
-				log("Warning: output message", outputMessageName, "not found in registry for service", serviceDescriptor.FullName())
-			} else {
-				messageRegistry[outputMessageName] = messageEntry{
-					message:        outputMessageEntry.message,
-					usedInRequest:  outputMessageEntry.usedInRequest,
-					usedInResponse: true,
-				}
-			}
+// checkFieldNames reports fields of a message that have the same name in the generated code.
+// JSON name conflicts are rejected by protoc, except in files where the json_format feature
+// is LEGACY_BEST_EFFORT, whose JSON mapping is then ambiguous.
+func (p *packageGenerator) checkFieldNames(message protoreflect.MessageDescriptor) {
+	names := make(map[string]protoreflect.FieldDescriptor)
+	rangeFields(message, func(field protoreflect.FieldDescriptor) {
+		name := p.options.fieldName(field)
+		if other, ok := names[name]; ok {
+			err := fmt.Errorf("(%s) fields %q and %q have the same name %q", message.FullName(), other.Name(), field.Name(), name)
+			p.addGenerationError(descriptorPosition(field), err)
+			return
 		}
+		names[name] = field
+	})
+}
+
+func (p *packageGenerator) generateFromRegistry(f *codegen.File) {
+	for _, e := range sortedDescriptors(p.enumRegistry) {
+		enumGenerator{pkg: p, enum: e}.Generate(f)
 	}
 
-	// Log all messages that are used in requests or responses
-	if options.verbose {
-		log("Messages used in requests:")
-		for name, entry := range messageRegistry {
-			if entry.usedInRequest {
-				log(" -", name)
-			}
-		}
-		log("Messages used in responses:")
-		for name, entry := range messageRegistry {
-			if entry.usedInResponse {
-				log(" -", name)
-			}
+	if len(p.serviceRegistry) > 0 {
+		var streaming bool
+		for s := range p.serviceRegistry {
+			streaming = streaming || hasServerStreaming(s)
 		}
+		GenerateServiceHeader(f, streaming)
+	}
+
+	for _, name := range sortedKeys(p.messageRegistry) {
+		messageGenerator{
+			pkg:          p,
+			message:      p.messageRegistry[name],
+			messageUsage: p.usage[name],
+		}.Generate(f)
+	}
+
+	for _, s := range sortedDescriptors(p.serviceRegistry) {
+		serviceGenerator{pkg: p, service: s}.Generate(f)
+	}
+	GeneratePathEncoders(f, p.pathEncoders)
+	p.generateCodecHelpers(f)
+	if p.streaming {
+		GenerateStreamResults(f)
 	}
 }
 
-func (p packageGenerator) generateFromRegistry(f *codegen.File) error {
-	GeneratePackageHeader(f)
+// typeName returns the name of a message variant or an enum in the generated file,
+// importing it from the file of the package that declares it when needed.
+func (p *packageGenerator) typeName(desc protoreflect.Descriptor, variant messageVariant) string {
+	name := suffixName(descriptorTypeName(desc), variant.suffix())
+	return p.importName(p.imports, desc.ParentFile().Package(), name)
+}
 
-	var walkErr error
+// valueName returns the local name of a function or constant declared by the file of a package,
+// importing it when the package is not the generated one.
+func (p *packageGenerator) valueName(pkg protoreflect.FullName, name string) string {
+	return p.importName(p.valueImports, pkg, name)
+}
 
-	for _, t := range wellKnownTypeRegistry {
-		f.Write(t.TypeDeclaration())
+func (p *packageGenerator) importName(imports map[protoreflect.FullName]map[string]string, pkg protoreflect.FullName, name string) string {
+	if pkg == p.name {
+		return name
 	}
-
-	for _, e := range enumRegistry {
-		enumGenerator{pkg: p.pkg, enum: e}.Generate(f)
+	if local, ok := imports[pkg][name]; ok {
+		return local
 	}
-
-	if len(serviceRegistry) > 0 {
-		GenerateServiceHeader(f)
+	local := name
+	_, declared := p.declaredNames[local]
+	_, imported := p.importedNames[local]
+	if declared || imported {
+		local = packagePrefix(pkg) + name
 	}
-
-	for _, m := range messageRegistry {
-		messageGenerator{
-			pkg:            p.pkg,
-			message:        m.message,
-			usedInRequest:  m.usedInRequest,
-			usedInResponse: m.usedInResponse,
-		}.Generate(f)
+	if imports[pkg] == nil {
+		imports[pkg] = make(map[string]string)
 	}
+	imports[pkg][name] = local
+	p.importedNames[local] = struct{}{}
+	return local
+}
 
-	for _, s := range serviceRegistry {
-		if err := (serviceGenerator{pkg: p.pkg, service: s.service}).Generate(f); err != nil {
-			walkErr = err
-			continue
+func (p *packageGenerator) generateImports(f *codegen.File) {
+	if p.options.pathErrorImport != "" && len(p.serviceRegistry) > 0 {
+		f.Write("import { ", p.options.pathError, " } from ", strconv.Quote(p.options.pathErrorImport), ";")
+	}
+	writeImports := func(keyword string, imports map[protoreflect.FullName]map[string]string) {
+		for _, pkg := range sortedKeys(imports) {
+			names := imports[pkg]
+			specifiers := make([]string, 0, len(names))
+			for _, name := range sortedKeys(names) {
+				if local := names[name]; local != name {
+					specifiers = append(specifiers, name+" as "+local)
+				} else {
+					specifiers = append(specifiers, name)
+				}
+			}
+			f.Write(keyword, " { ", strings.Join(specifiers, ", "), " } from ", strconv.Quote(importPath(p.name, pkg)), ";")
 		}
 	}
+	writeImports("import type", p.imports)
+	writeImports("import", p.valueImports)
+	if len(p.imports) > 0 || len(p.valueImports) > 0 || (p.options.pathErrorImport != "" && len(p.serviceRegistry) > 0) {
+		f.Write()
+	}
+}
 
-	return walkErr
+// registerWellKnownType registers a well known type to be declared in the file, together with the types it depends on.
+func (p *packageGenerator) registerWellKnownType(wkt WellKnown) {
+	p.wellKnownTypeRegistry[wkt] = wkt
+	switch wkt {
+	case WellKnownStruct, WellKnownValue, WellKnownListValue:
+		p.wellKnownTypeRegistry[wellKnownJSONValue] = wellKnownJSONValue
+	}
 }
 
 func GeneratePackageHeader(f *codegen.File) {
 	f.Write("// Code generated by protoc-gen-typescript-http. DO NOT EDIT.")
 	f.Write("/* eslint-disable camelcase */")
-	f.Write("// @ts-nocheck")
 	f.Write()
 }
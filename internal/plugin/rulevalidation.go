@@ -0,0 +1,86 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/evad1n/protoc-gen-typescript-http/internal/httprule"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validateRule reports the path variables and body of the bindings of a method that do not refer
+// to fields of the request as required by google.api.http, and returns whether all of them are valid.
+// Additional bindings must also have the response_body of the primary binding, which types the method.
+func (s serviceGenerator) validateRule(method protoreflect.MethodDescriptor, rule httprule.Rule) bool {
+	valid := true
+	for _, binding := range append([]httprule.Rule{rule}, rule.AdditionalRules...) {
+		problems := bindingProblems(method.Input(), binding)
+		if binding.ResponseBody != rule.ResponseBody {
+			problems = append(problems, fmt.Sprintf(
+				"response_body %q differs from the response_body %q of the primary binding",
+				binding.ResponseBody, rule.ResponseBody,
+			))
+		}
+		for _, problem := range problems {
+			err := fmt.Errorf("(%s) http rule %q: %s", method.FullName(), binding.Method+" "+binding.Template.String(), problem)
+			s.pkg.addGenerationError(httpRulePosition(method), err)
+			valid = false
+		}
+	}
+	return valid
+}
+
+// bindingProblems returns the problems of the fields referred to by a single http binding.
+func bindingProblems(input protoreflect.MessageDescriptor, rule httprule.Rule) []string {
+	var problems []string
+	for _, seg := range rule.Template.Segments {
+		if seg.Kind != httprule.SegmentKindVariable {
+			continue
+		}
+		if problem := pathVariableProblem(input, seg.Variable.FieldPath); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if rule.Body != "" && rule.Body != "*" {
+		field := input.Fields().ByName(protoreflect.Name(rule.Body))
+		switch {
+		case strings.Contains(rule.Body, "."):
+			problems = append(problems, fmt.Sprintf("body %q must be a top-level field of %q", rule.Body, input.FullName()))
+		case field == nil:
+			problems = append(problems, fmt.Sprintf("body field %q not found in message %q", rule.Body, input.FullName()))
+		case field.Cardinality() == protoreflect.Repeated:
+			problems = append(problems, fmt.Sprintf("body field %q must not be repeated", rule.Body))
+		}
+	}
+	return problems
+}
+
+// pathVariableProblem returns the problem of the field path of a path variable, or an empty string
+// if it refers to a non-repeated field of a primitive type through non-repeated message fields.
+func pathVariableProblem(input protoreflect.MessageDescriptor, path httprule.FieldPath) string {
+	message := input
+	for i, name := range path {
+		field := message.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Sprintf("path variable %q: field %q not found in message %q", path, name, message.FullName())
+		}
+		if field.Cardinality() == protoreflect.Repeated {
+			return fmt.Sprintf("path variable %q: field %q must not be repeated", path, name)
+		}
+		if i == len(path)-1 {
+			if field.Message() != nil {
+				return fmt.Sprintf("path variable %q: field %q must have a primitive type", path, name)
+			}
+			return ""
+		}
+		if field.Message() == nil {
+			return fmt.Sprintf("path variable %q: field %q must be a message", path, name)
+		}
+		if IsWellKnownType(field.Message()) {
+			// Well known types have a special JSON mapping, without fields to refer to
+			return fmt.Sprintf("path variable %q: field %q must not be a well known type", path, name)
+		}
+		message = field.Message()
+	}
+	return ""
+}
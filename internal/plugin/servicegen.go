@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -11,11 +12,11 @@ import (
 )
 
 type serviceGenerator struct {
-	pkg     protoreflect.FullName
+	pkg     *packageGenerator
 	service protoreflect.ServiceDescriptor
 }
 
-func GenerateServiceHeader(f *codegen.File) {
+func GenerateServiceHeader(f *codegen.File, streaming bool) {
 	f.Write("type RequestType = {")
 	f.Write(indentBy(1), "path: string;")
 	f.Write(indentBy(1), "method: string;")
@@ -24,131 +25,409 @@ func GenerateServiceHeader(f *codegen.File) {
 	f.Write()
 	f.Write("type RequestHandler = (request: RequestType, meta: { service: string, method: string }) => Promise<unknown>;")
 	f.Write()
+	if streaming {
+		f.Write("/**")
+		f.Write(" * Sends a request to a server streaming method and yields each chunk of the response,")
+		f.Write(" * parsed from JSON, such as the newline-delimited {\"result\": ...} or {\"error\": ...}")
+		f.Write(" * objects streamed by grpc-gateway.")
+		f.Write(" */")
+		f.Write("type StreamingRequestHandler = (request: RequestType, meta: { service: string, method: string }) => AsyncIterable<unknown>;")
+		f.Write()
+	}
+}
+
+// GenerateStreamResults generates the function used by clients to unwrap the chunks of server streaming responses.
+func GenerateStreamResults(f *codegen.File) {
+	f.Write()
+	f.Write("/**")
+	f.Write(" * Yields the results of a server streaming response, and throws the first error it contains.")
+	f.Write(" */")
+	f.Write("async function* streamResults<T>(")
+	f.Write(indentBy(1), "chunks: AsyncIterable<unknown>,")
+	f.Write(indentBy(1), "decode: (result: any) => T, // eslint-disable-line @typescript-eslint/no-explicit-any")
+	f.Write("): AsyncIterable<T> {")
+	f.Write(indentBy(1), "for await (const chunk of chunks as AsyncIterable<{ result?: unknown; error?: { message?: string } }>) {")
+	f.Write(indentBy(2), "if (chunk.error !== undefined && chunk.error !== null) {")
+	f.Write(indentBy(3), "throw Object.assign(new Error(chunk.error.message ?? \"stream error\"), chunk.error);")
+	f.Write(indentBy(2), "}")
+	f.Write(indentBy(2), "yield decode(chunk.result);")
+	f.Write(indentBy(1), "}")
+	f.Write("}")
+}
+
+// hasServerStreaming reports whether a service has server streaming methods that clients are generated for.
+func hasServerStreaming(service protoreflect.ServiceDescriptor) bool {
+	var streaming bool
+	rangeMethods(service.Methods(), func(method protoreflect.MethodDescriptor) {
+		if supportedMethod(method) && method.IsStreamingServer() {
+			streaming = true
+		}
+	})
+	return streaming
+}
+
+// GeneratePathEncoders generates the functions used by clients to encode path variables,
+// following the rules of google.api.http.
+func GeneratePathEncoders(f *codegen.File, encoders map[string]struct{}) {
+	if len(encoders) == 0 {
+		return
+	}
+	f.Write()
+	// encodePathSegments depends on encodePathSegment
+	f.Write("/**")
+	f.Write(" * Percent-encodes all characters except [-_.~0-9a-zA-Z].")
+	f.Write(" */")
+	f.Write("function encodePathSegment(value: string): string {")
+	f.Write(indentBy(1), "return encodeURIComponent(value).replace(/[!'()*]/g, (c) => `%${c.charCodeAt(0).toString(16).toUpperCase()}`);")
+	f.Write("}")
+	if _, ok := encoders["encodePathSegments"]; ok {
+		f.Write()
+		f.Write("/**")
+		f.Write(" * Percent-encodes all characters except [-_.~/0-9a-zA-Z].")
+		f.Write(" */")
+		f.Write("function encodePathSegments(value: string): string {")
+		f.Write(indentBy(1), "return value.split(\"/\").map(encodePathSegment).join(\"/\");")
+		f.Write("}")
+	}
 }
 
-func (s serviceGenerator) Generate(f *codegen.File) error {
+func (s serviceGenerator) Generate(f *codegen.File) {
+	s.reportSkippedMethods()
 	s.generateInterface(f)
-	return s.generateClient(f)
+	s.generateClient(f)
 }
 
 func (s serviceGenerator) generateInterface(f *codegen.File) {
-	commentGenerator{descriptor: s.service}.generateLeading(f, 0)
+	commentGenerator{pkg: s.pkg, descriptor: s.service}.generateLeading(f, 0)
 	f.Write("export interface ", descriptorTypeName(s.service), " {")
 	rangeMethods(s.service.Methods(), func(method protoreflect.MethodDescriptor) {
 		if !supportedMethod(method) {
 			return
 		}
-		commentGenerator{descriptor: method}.generateLeading(f, 1)
-		input := typeFromMessage(s.pkg, method.Input())
-		output := typeFromMessage(s.pkg, method.Output())
-
-		inputName := suffixName(input.Reference(), REQUEST_SUFFIX)
-		if _, ok := WellKnownType(method.Input()); ok {
-			inputName = input.Reference()
+		commentGenerator{pkg: s.pkg, descriptor: method}.generateLeading(f, 1)
+		input := s.pkg.typeFromMessage(method.Input(), s.pkg.methodVariant(method.Input(), requestVariant))
+		var output string
+		if httpRule, ok := httprule.Get(method); ok {
+			if rule, err := httprule.ParseRule(httpRule); err == nil {
+				output = s.responseType(method, rule)
+			}
 		}
-		outputName := suffixName(output.Reference(), RESPONSE_SUFFIX)
-		if _, ok := WellKnownType(method.Output()); ok {
-			outputName = output.Reference()
+		if output == "" {
+			output = s.pkg.typeFromMessage(method.Output(), s.pkg.methodVariant(method.Output(), responseVariant)).Reference()
+		}
+		if method.IsStreamingServer() {
+			f.Write(indentBy(1), method.Name(), "(request: ", input.Reference(), "): AsyncIterable<", output, ">;")
+		} else {
+			f.Write(indentBy(1), method.Name(), "(request: ", input.Reference(), "): Promise<", output, ">;")
 		}
-
-		f.Write(indentBy(1), method.Name(), "(request: ", inputName, "): Promise<", outputName, ">;")
 	})
 	f.Write("}")
 	f.Write()
 }
 
-func (s serviceGenerator) generateClient(f *codegen.File) error {
+func (s serviceGenerator) generateClient(f *codegen.File) {
+	handlers := "handler: RequestHandler"
+	if hasServerStreaming(s.service) {
+		handlers += ",\n" + indentBy(1) + "streamingHandler: StreamingRequestHandler"
+	}
 	f.Write(
 		"export function create",
 		descriptorTypeName(s.service),
 		"Client(",
 		"\n",
 		indentBy(1),
-		"handler: RequestHandler",
+		handlers,
 		"\n",
 		"): ",
 		descriptorTypeName(s.service),
 		" {",
 	)
 	f.Write(indentBy(1), "return {")
-	var methodErr error
 	rangeMethods(s.service.Methods(), func(method protoreflect.MethodDescriptor) {
-		if err := s.generateMethod(f, method); err != nil {
-			methodErr = fmt.Errorf("generate method %s: %w", method.Name(), err)
-		}
+		s.generateMethod(f, method)
 	})
-	if methodErr != nil {
-		return methodErr
-	}
 	f.Write(indentBy(1), "};")
 	f.Write("}")
-	return nil
 }
 
-func (s serviceGenerator) generateMethod(f *codegen.File, method protoreflect.MethodDescriptor) error {
-	outputType := typeFromMessage(s.pkg, method.Output())
-	httpRule, ok := httprule.Get(method)
-	if !ok {
-		return nil
+func (s serviceGenerator) generateMethod(f *codegen.File, method protoreflect.MethodDescriptor) {
+	if !supportedMethod(method) {
+		return
 	}
+	httpRule, _ := httprule.Get(method)
 	rule, err := httprule.ParseRule(httpRule)
 	if err != nil {
-		return fmt.Errorf("parse http rule: %w", err)
+		s.pkg.addGenerationError(httpRulePosition(method), fmt.Errorf("(%s) invalid http rule: %w", method.FullName(), err))
+		return
+	}
+	if !s.validateRule(method, rule) {
+		return
 	}
-	logV("generating method:", method.FullName(), httpRule)
+	s.pkg.logV("generating method:", method.FullName(), httpRule)
+	// All bindings have the response_body of the primary binding, which types the method
+	output := s.responseType(method, rule)
 	f.Write(indentBy(2), method.Name(), "(request) { // eslint-disable-line @typescript-eslint/no-unused-vars")
-	s.generateMethodPathValidation(f, method, rule)
-	s.generateMethodPath(f, method, rule)
-	s.generateMethodBody(f, method, rule)
-	s.generateMethodQuery(f, method, rule)
+	bindings := s.usedBindings(method, rule)
+	if len(bindings) == 1 {
+		s.generateMethodPathValidation(f, method, rule, 3)
+		f.Write(indentBy(3), "const path = ", s.methodPath(method, rule), "; // eslint-disable-line quotes")
+		f.Write(indentBy(3), "const body = ", s.methodBody(method, rule), ";")
+		f.Write(indentBy(3), "const queryParams: string[] = [];")
+		s.generateMethodQuery(f, method, rule, 3)
+		s.generateMethodRequest(f, method, rule, strconv.Quote(rule.Method), output)
+		f.Write(indentBy(2), "},")
+		return
+	}
+	// The query parameters are shared by the bindings unless they differ in the fields covered
+	// by the path or body.
+	queries := make([]*codegen.File, len(bindings))
+	sharedQuery := true
+	for i, binding := range bindings {
+		queries[i] = &codegen.File{}
+		s.generateMethodQuery(queries[i], method, binding, 4)
+		sharedQuery = sharedQuery && string(queries[i].Content()) == string(queries[0].Content())
+	}
+	f.Write(indentBy(3), "let path: string;")
+	f.Write(indentBy(3), "let method: string;")
+	f.Write(indentBy(3), "let body: string | null;")
+	f.Write(indentBy(3), "const queryParams: string[] = [];")
+	for i, binding := range bindings {
+		switch condition := s.bindingCondition(method, binding); {
+		case i == 0:
+			f.Write(indentBy(3), "if (", condition, ") {")
+		case condition == "true":
+			f.Write(indentBy(3), "} else {")
+		default:
+			f.Write(indentBy(3), "} else if (", condition, ") {")
+		}
+		f.Write(indentBy(4), "path = ", s.methodPath(method, binding), "; // eslint-disable-line quotes")
+		f.Write(indentBy(4), "method = ", strconv.Quote(binding.Method), ";")
+		f.Write(indentBy(4), "body = ", s.methodBody(method, binding), ";")
+		if !sharedQuery {
+			f.Append(queries[i])
+		}
+	}
+	if s.bindingCondition(method, bindings[len(bindings)-1]) != "true" {
+		errMsg := "request does not match any http binding of " + string(method.Parent().Name()) + "." + string(method.Name())
+		f.Write(indentBy(3), "} else {")
+		f.Write(indentBy(4), "throw new ", s.pkg.options.pathError, "(", strconv.Quote(errMsg), ");")
+	}
+	f.Write(indentBy(3), "}")
+	if sharedQuery {
+		s.generateMethodQuery(f, method, rule, 3)
+	}
+	s.generateMethodRequest(f, method, rule, "method", output)
+	f.Write(indentBy(2), "},")
+}
+
+// generateMethodRequest generates the request to the handler, given the path, body and query
+// parameters of the method, and an expression for the http method.
+func (s serviceGenerator) generateMethodRequest(
+	f *codegen.File,
+	method protoreflect.MethodDescriptor,
+	rule httprule.Rule,
+	httpMethod string,
+	output string,
+) {
 	f.Write(indentBy(3), "let uri = path;")
 	f.Write(indentBy(3), "if (queryParams.length > 0) {")
 	f.Write(indentBy(4), "uri += `?${queryParams.join(\"&\")}`")
 	f.Write(indentBy(3), "}")
-	f.Write(indentBy(3), "return handler({")
+	if method.IsStreamingServer() {
+		s.pkg.streaming = true
+		f.Write(indentBy(3), "return streamResults<", output, ">(streamingHandler({")
+	} else {
+		f.Write(indentBy(3), "return handler({")
+	}
 	f.Write(indentBy(4), "path: uri,")
-	f.Write(indentBy(4), "method: ", strconv.Quote(rule.Method), ",")
+	f.Write(indentBy(4), "method: ", httpMethod, ",")
 	f.Write(indentBy(4), "body,")
 	f.Write(indentBy(3), "}, {")
 	f.Write(indentBy(4), "service: \"", method.Parent().Name(), "\",")
 	f.Write(indentBy(4), "method: \"", method.Name(), "\",")
-	f.Write(indentBy(3), "}) as Promise<", outputType.Reference(), ">;")
-	f.Write(indentBy(2), "},")
-	return nil
+	convert := s.responseConversion(method, rule)
+	switch {
+	case method.IsStreamingServer() && convert == nil:
+		f.Write(indentBy(3), "}), (result) => result);")
+	case method.IsStreamingServer():
+		f.Write(indentBy(3), "}), (result) => ", convert("result"), ");")
+	case convert == nil:
+		f.Write(indentBy(3), "}) as Promise<", output, ">;")
+	case rule.ResponseBody == "":
+		f.Write(indentBy(3), "}).then((response) => ", convert("response"), ");")
+	default:
+		f.Write(
+			indentBy(3),
+			"}).then((response: any) => ", convert("response"),
+			") as Promise<", output, ">; // eslint-disable-line @typescript-eslint/no-explicit-any",
+		)
+	}
+}
+
+// responseConversion returns a function returning an expression converting a response when the
+// numeric_enums option or the int64, timestamp and duration options convert values in it, or nil
+// if it is not converted.
+func (s serviceGenerator) responseConversion(
+	method protoreflect.MethodDescriptor,
+	rule httprule.Rule,
+) func(value string) string {
+	var field protoreflect.FieldDescriptor
+	if rule.ResponseBody != "" {
+		field = leafField(httprule.FieldPath(strings.Split(rule.ResponseBody, ".")), method.Output())
+		if field == nil {
+			return nil
+		}
+	}
+	var converts []func(value string) string
+	for _, t := range s.responseTransforms() {
+		if field != nil {
+			if convert := t.fieldConversion(s.pkg, field); convert != nil {
+				converts = append(converts, convert)
+			}
+			continue
+		}
+		if name := t.messageConversion(s.pkg, method.Output(), s.pkg.methodVariant(method.Output(), responseVariant)); name != "" {
+			converts = append(converts, func(value string) string {
+				return name + "(" + value + ")"
+			})
+		}
+	}
+	if len(converts) == 0 {
+		return nil
+	}
+	convert := func(value string) string {
+		for _, c := range converts {
+			value = c(value)
+		}
+		return value
+	}
+	if field == nil {
+		return convert
+	}
+	return func(value string) string {
+		return value + " === undefined || " + value + " === null ? " + value + " : " + convert(value)
+	}
+}
+
+// responseTransforms returns the transforms that clients apply to responses, in order:
+// enum values are normalized from the JSON as received, before values are decoded.
+func (s serviceGenerator) responseTransforms() []messageTransform {
+	var transforms []messageTransform
+	if s.pkg.options.numericEnums {
+		transforms = append(transforms, normalizeEnumsTransform)
+	}
+	if s.pkg.options.usesCodec() {
+		transforms = append(transforms, decodeTransform(s.pkg.options))
+	}
+	return transforms
+}
+
+// pathVariableValue returns the value of a path variable in the request as a string.
+func (s serviceGenerator) pathVariableValue(v httprule.VariableSegment, method protoreflect.MethodDescriptor) string {
+	value := "request." + s.jsonPath(v.FieldPath, method)
+	if field := leafField(v.FieldPath, method.Input()); field == nil || field.Kind() != protoreflect.StringKind {
+		value = "String(" + value + ")"
+	}
+	return value
+}
+
+// responseType returns the type of the response of a method binding, which is the type
+// of the response_body field when the binding has one.
+func (s serviceGenerator) responseType(method protoreflect.MethodDescriptor, rule httprule.Rule) string {
+	if rule.ResponseBody == "" {
+		return s.pkg.typeFromMessage(method.Output(), s.pkg.methodVariant(method.Output(), responseVariant)).Reference()
+	}
+	fields := s.resolveFieldPath(httprule.FieldPath(strings.Split(rule.ResponseBody, ".")), method, method.Output())
+	if len(fields) == 0 || fields[len(fields)-1] == nil {
+		return "unknown"
+	}
+	return s.pkg.typeFromField(fields[len(fields)-1], responseVariant).Reference()
+}
+
+// usedBindings returns the bindings of a method that clients may use, in order. The first binding
+// whose path variables match the request is used, so a binding with the condition of an earlier
+// binding, or after one without path variables, is never used. It is skipped with a warning.
+func (s serviceGenerator) usedBindings(method protoreflect.MethodDescriptor, rule httprule.Rule) []httprule.Rule {
+	var bindings []httprule.Rule
+	conditions := make(map[string]bool)
+	for _, binding := range append([]httprule.Rule{rule}, rule.AdditionalRules...) {
+		condition := s.bindingCondition(method, binding)
+		if conditions[condition] || conditions["true"] {
+			s.pkg.addWarning(fmt.Sprintf(
+				"%s: (%s) http rule %q is never used, since an earlier binding matches the same requests",
+				httpRulePosition(method), method.FullName(), binding.Method+" "+binding.Template.String(),
+			))
+			continue
+		}
+		conditions[condition] = true
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
+
+// bindingCondition returns a condition that is true when the path variables of the request match the binding.
+func (s serviceGenerator) bindingCondition(method protoreflect.MethodDescriptor, rule httprule.Rule) string {
+	var conditions []string
+	for _, seg := range rule.Template.Segments {
+		if seg.Kind != httprule.SegmentKindVariable {
+			continue
+		}
+		conditions = append(conditions, "request."+s.nullPropagationPath(seg.Variable.FieldPath, method))
+		if isSingleSegmentVariable(seg.Variable) {
+			continue
+		}
+		value := s.pathVariableValue(seg.Variable, method)
+		conditions = append(conditions, "/^"+variablePattern(seg.Variable.Segments)+"$/.test("+value+")")
+	}
+	if len(conditions) == 0 {
+		return "true"
+	}
+	return strings.Join(conditions, " && ")
 }
 
 func (s serviceGenerator) generateMethodPathValidation(
 	f *codegen.File,
 	method protoreflect.MethodDescriptor,
 	rule httprule.Rule,
+	indent int,
 ) {
-
-	// fmt.Fprintln(os.Stderr, "generateMethodPathValidation", rule.Method, rule.Template.Segments)
 	for _, seg := range rule.Template.Segments {
 		if seg.Kind != httprule.SegmentKindVariable {
 			continue
 		}
 		fp := seg.Variable.FieldPath
-		nullPath := nullPropagationPath(fp, method)
+		nullPath := s.nullPropagationPath(fp, method)
 		protoPath := strings.Join(fp, ".")
 		errMsg := "missing required field request." + protoPath
-		f.Write(indentBy(3), "if (!request.", nullPath, ") {")
-		f.Write(indentBy(4), "throw new Error(", strconv.Quote(errMsg), ");")
-		f.Write(indentBy(3), "}")
+		f.Write(indentBy(indent), "if (!request.", nullPath, ") {")
+		f.Write(indentBy(indent+1), "throw new ", s.pkg.options.pathError, "(", strconv.Quote(errMsg), ");")
+		f.Write(indentBy(indent), "}")
+		if isSingleSegmentVariable(seg.Variable) {
+			continue
+		}
+		// Literal segments and the number of segments of the value must match the variable template
+		value := s.pathVariableValue(seg.Variable, method)
+		errMsg = fmt.Sprintf("field request.%s must match %q, got: ", protoPath, seg.Variable.SegmentsString())
+		f.Write(indentBy(indent), "if (!/^", variablePattern(seg.Variable.Segments), "$/.test(", value, ")) {")
+		f.Write(indentBy(indent+1), "throw new ", s.pkg.options.pathError, "(", strconv.Quote(errMsg), " + JSON.stringify(", value, "));")
+		f.Write(indentBy(indent), "}")
 	}
 }
 
-func (s serviceGenerator) generateMethodPath(
-	f *codegen.File,
-	method protoreflect.MethodDescriptor,
-	rule httprule.Rule,
-) {
+// methodPath returns a template literal of the path of a method binding.
+func (s serviceGenerator) methodPath(method protoreflect.MethodDescriptor, rule httprule.Rule) string {
 	pathParts := make([]string, 0, len(rule.Template.Segments))
 	for _, seg := range rule.Template.Segments {
 		switch seg.Kind {
 		case httprule.SegmentKindVariable:
-			fieldPath := jsonPath(seg.Variable.FieldPath, method)
-			pathParts = append(pathParts, "${request."+fieldPath+"}")
+			// Variables matching a single segment are fully percent-encoded, while variables
+			// matching multiple segments keep their '/' separators.
+			encode := "encodePathSegment"
+			if !isSingleSegmentVariable(seg.Variable) {
+				encode = "encodePathSegments"
+			}
+			s.pkg.pathEncoders[encode] = struct{}{}
+			pathParts = append(pathParts, "${"+encode+"("+s.pathVariableValue(seg.Variable, method)+")}")
 		case httprule.SegmentKindLiteral:
 			pathParts = append(pathParts, seg.Literal)
 		case httprule.SegmentKindMatchSingle: // TODO: Double check this and following case
@@ -161,22 +440,31 @@ func (s serviceGenerator) generateMethodPath(
 	if rule.Template.Verb != "" {
 		path += ":" + rule.Template.Verb
 	}
-	f.Write(indentBy(3), "const path = `", path, "`; // eslint-disable-line quotes")
+	return "`" + path + "`"
 }
 
-func (s serviceGenerator) generateMethodBody(
-	f *codegen.File,
-	method protoreflect.MethodDescriptor,
-	rule httprule.Rule,
-) {
+// methodBody returns an expression of the body of a method binding.
+func (s serviceGenerator) methodBody(method protoreflect.MethodDescriptor, rule httprule.Rule) string {
+	encode := encodeTransform(s.pkg.options)
 	switch {
 	case rule.Body == "":
-		f.Write(indentBy(3), "const body = null;")
+		return "null"
 	case rule.Body == "*":
-		f.Write(indentBy(3), "const body = JSON.stringify(request);")
+		value := "request"
+		if name := encode.messageConversion(s.pkg, method.Input(), s.pkg.methodVariant(method.Input(), requestVariant)); name != "" && s.pkg.options.usesCodec() {
+			value = name + "(request)"
+		}
+		return "JSON.stringify(" + value + ")"
 	default:
-		nullPath := nullPropagationPath(httprule.FieldPath{rule.Body}, method)
-		f.Write(indentBy(3), "const body = JSON.stringify(request?.", nullPath, " ?? {});")
+		nullPath := s.nullPropagationPath(httprule.FieldPath{rule.Body}, method)
+		field := method.Input().Fields().ByName(protoreflect.Name(rule.Body))
+		if field != nil && s.pkg.options.usesCodec() {
+			if convert := encode.fieldConversion(s.pkg, field); convert != nil {
+				value := "request." + nullPath
+				return "JSON.stringify(" + value + " !== undefined && " + value + " !== null ? " + convert(value) + " : {})"
+			}
+		}
+		return "JSON.stringify(request?." + nullPath + " ?? {})"
 	}
 }
 
@@ -184,8 +472,8 @@ func (s serviceGenerator) generateMethodQuery(
 	f *codegen.File,
 	method protoreflect.MethodDescriptor,
 	rule httprule.Rule,
+	indent int,
 ) {
-	f.Write(indentBy(3), "const queryParams: string[] = [];")
 	// nothing in query
 	if rule.Body == "*" {
 		return
@@ -205,49 +493,254 @@ func (s serviceGenerator) generateMethodQuery(
 		if rule.Body != "" && path[0] == rule.Body {
 			return
 		}
-		nullPath := nullPropagationPath(path, method)
-		jp := jsonPath(path, method)
-		f.Write(indentBy(3), "if (request.", nullPath, ") {")
+		if err := queryParameterSupported(field); err != nil {
+			err = fmt.Errorf("(%s) query parameter %q: %w", method.FullName(), path.String(), err)
+			s.pkg.addGenerationError(httpRulePosition(method), err)
+			return
+		}
+		if reason := queryParameterSkipReason(field); reason != "" {
+			s.pkg.addWarning(fmt.Sprintf("%s: (%s) query parameter %q: %s", httpRulePosition(method), method.FullName(), path.String(), reason))
+			return
+		}
+		if wkt, ok := WellKnownType(field.Message()); ok && wkt == WellKnownEmpty {
+			// An empty message has no value to send
+			return
+		}
+		nullPath := s.nullPropagationPath(path, method)
+		jp := s.jsonPath(path, method)
+		// Fields with explicit presence are sent whenever they are set, even to their zero value,
+		// while fields without presence are skipped when they have their zero value.
+		switch {
+		case field.HasPresence():
+			f.Write(indentBy(indent), "if (request.", nullPath, " !== undefined && request.", nullPath, " !== null) {")
+		case field.IsList(), field.IsMap():
+			f.Write(indentBy(indent), "if (request.", nullPath, ") {")
+		case strings.Contains(nullPath, "?.") || getFieldCardinalitySymbol(field, requestVariant, false) == "?":
+			f.Write(indentBy(indent), "if (request.", nullPath, " !== undefined && request.", nullPath, " !== ", s.zeroValue(field), ") {")
+		default:
+			f.Write(indentBy(indent), "if (request.", nullPath, " !== ", s.zeroValue(field), ") {")
+		}
 		switch {
+		case field.IsMap():
+			f.Write(indentBy(indent+1), "Object.entries(request.", jp, ").forEach(([key, value]) => {")
+			f.Write(indentBy(indent+2), "queryParams.push(`", jp, "[${encodeURIComponent(key)}]=${encodeURIComponent(", s.queryValue("value", field.MapValue()), ")}`)")
+			f.Write(indentBy(indent+1), "})")
 		case field.IsList():
-			f.Write(indentBy(4), "request.", jp, ".forEach((x) => {")
-			f.Write(indentBy(5), "queryParams.push(`", jp, "=${encodeURIComponent(x.toString())}`)")
-			f.Write(indentBy(4), "})")
+			f.Write(indentBy(indent+1), "request.", jp, ".forEach((x) => {")
+			f.Write(indentBy(indent+2), "queryParams.push(`", jp, "=${encodeURIComponent(", s.queryValue("x", field), ")}`)")
+			f.Write(indentBy(indent+1), "})")
 		default:
-			f.Write(indentBy(4), "queryParams.push(`", jp, "=${encodeURIComponent(request.", jp, ".toString())}`)")
+			f.Write(indentBy(indent+1), "queryParams.push(`", jp, "=${encodeURIComponent(", s.queryValue("request."+jp, field), ")}`)")
 		}
-		f.Write(indentBy(3), "}")
+		f.Write(indentBy(indent), "}")
 	})
 }
 
-func supportedMethod(method protoreflect.MethodDescriptor) bool {
+// queryParameterSupported returns an error if values of the field cannot be sent as query parameters.
+// Following grpc-gateway, only scalars, enums and well known types with a string or scalar JSON
+// representation are supported, including in maps and repeated fields.
+// queryParameterSupported returns an error for repeated message fields, which cannot be sent as
+// query parameters.
+func queryParameterSupported(field protoreflect.FieldDescriptor) error {
+	if !field.IsList() || field.Message() == nil {
+		return nil
+	}
+	if _, ok := WellKnownType(field.Message()); !ok {
+		return fmt.Errorf("repeated message fields cannot be sent as query parameters")
+	}
+	return nil
+}
+
+// queryParameterSkipReason returns the reason why a field that is not covered by the path or body
+// is not sent as a query parameter, or an empty string if it is. These fields have no defined
+// query encoding, but a client can still call the method without them.
+func queryParameterSkipReason(field protoreflect.FieldDescriptor) string {
+	value := field
+	if field.IsMap() {
+		value = field.MapValue()
+	}
+	if value.Kind() != protoreflect.MessageKind && value.Kind() != protoreflect.GroupKind {
+		return ""
+	}
+	wkt, ok := WellKnownType(value.Message())
+	switch {
+	case !ok && field.IsMap():
+		return "map fields with message values cannot be sent as query parameters, the field is skipped"
+	case wkt == WellKnownAny, wkt == WellKnownStruct, wkt == WellKnownValue, wkt == WellKnownListValue:
+		return fmt.Sprintf("%s cannot be sent as query parameters, the field is skipped", wkt)
+	}
+	return ""
+}
+
+// queryValue returns an expression encoding a value of a field as a query parameter value.
+// Enums are sent by name, and well known types in their JSON string representation,
+// which is how they are represented in the generated types unless they are converted by the
+// timestamp and duration options. Wrapper types are unwrapped.
+// zeroValue returns the zero value of a singular field without presence, as typed in requests.
+func (s serviceGenerator) zeroValue(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return "false"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return `""`
+	case protoreflect.EnumKind:
+		if _, ok := WellKnownType(field.Enum()); ok {
+			return "null"
+		}
+		// Fields without presence have open enums, which always have a value numbered zero
+		return strconv.Quote(string(field.Enum().Values().ByNumber(0).Name()))
+	case
+		protoreflect.Int64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Sint64Kind:
+		switch s.pkg.options.int64Type(field) {
+		case int64AsString:
+			return `"0"`
+		case int64AsBigint:
+			return "0n"
+		}
+	}
+	return "0"
+}
+
+func (s serviceGenerator) queryValue(value string, field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind:
+		return value
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		wkt, _ := WellKnownType(field.Message())
+		if s.pkg.options.convertsWellKnownType(wkt) {
+			return s.pkg.codecHelper(encoderName(wkt)) + "(" + value + ")"
+		}
+		switch wkt {
+		case WellKnownTimestamp, WellKnownDuration, WellKnownFieldMask, WellKnownStringValue, WellKnownBytesValue:
+			return value
+		}
+	}
+	return "String(" + value + ")"
+}
+
+// reportSkippedMethods reports the methods of the service that no client method is generated for.
+// They are generation errors with the strict option, or when they are annotated with an http rule
+// that cannot be called, and warnings otherwise.
+func (s serviceGenerator) reportSkippedMethods() {
+	rangeMethods(s.service.Methods(), func(method protoreflect.MethodDescriptor) {
+		reason := skipReason(method)
+		if reason == "" {
+			return
+		}
+		if _, annotated := httprule.Get(method); annotated {
+			s.pkg.addGenerationError(httpRulePosition(method), fmt.Errorf("(%s) %s", method.FullName(), reason))
+			return
+		}
+		if s.pkg.options.strict {
+			s.pkg.addGenerationError(descriptorPosition(method), fmt.Errorf("(%s) %s", method.FullName(), reason))
+			return
+		}
+		s.pkg.addWarning(fmt.Sprintf("%s: (%s) %s", descriptorPosition(method), method.FullName(), reason))
+	})
+}
+
+// skipReason returns the reason why no client method is generated for a method,
+// or an empty string if one is.
+func skipReason(method protoreflect.MethodDescriptor) string {
 	_, ok := httprule.Get(method)
-	return ok && !method.IsStreamingClient() && !method.IsStreamingServer()
+	switch {
+	case method.IsStreamingClient():
+		return "client streaming methods cannot be called over http"
+	case !ok:
+		return "missing google.api.http annotation"
+	}
+	return ""
 }
 
-func jsonPath(path httprule.FieldPath, method protoreflect.MethodDescriptor) string {
-	return strings.Join(jsonPathSegments(path, method), ".")
+func supportedMethod(method protoreflect.MethodDescriptor) bool {
+	return skipReason(method) == ""
 }
 
-func nullPropagationPath(path httprule.FieldPath, method protoreflect.MethodDescriptor) string {
-	return strings.Join(jsonPathSegments(path, method), "?.")
+func (s serviceGenerator) jsonPath(path httprule.FieldPath, method protoreflect.MethodDescriptor) string {
+	return strings.Join(s.jsonPathSegments(path, method), ".")
 }
 
-func jsonPathSegments(path httprule.FieldPath, method protoreflect.MethodDescriptor) []string {
-	message := method.Input()
+func (s serviceGenerator) nullPropagationPath(path httprule.FieldPath, method protoreflect.MethodDescriptor) string {
+	return strings.Join(s.jsonPathSegments(path, method), "?.")
+}
+
+func (s serviceGenerator) jsonPathSegments(path httprule.FieldPath, method protoreflect.MethodDescriptor) []string {
+	fields := s.resolveFieldPath(path, method, method.Input())
 	segs := make([]string, len(path))
+	for i, field := range fields {
+		if field != nil {
+			segs[i] = s.pkg.options.fieldName(field)
+		}
+	}
+	return segs
+}
+
+// resolveFieldPath returns the fields along a field path in a message of the method.
+// Fields that are not found, or follow a field that is not a message, are nil and are
+// reported as generation errors.
+func (s serviceGenerator) resolveFieldPath(
+	path httprule.FieldPath,
+	method protoreflect.MethodDescriptor,
+	message protoreflect.MessageDescriptor,
+) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, len(path))
 	for i, p := range path {
+		if message == nil {
+			err := fmt.Errorf("(%s) field %q is not a message", method.FullName(), path[i-1])
+			s.pkg.addGenerationError(httpRulePosition(method), err)
+			break
+		}
 		field := message.Fields().ByName(protoreflect.Name(p))
 		if field == nil {
-			err := fmt.Errorf("ERROR: (%s) field %q not found in message %q", method.FullName(), p, message.FullName())
-			logV(err)
-			addGenerationError(err)
-		} else {
-			segs[i] = field.JSONName()
-			if i < len(path) {
-				message = field.Message()
-			}
+			err := fmt.Errorf("(%s) field %q not found in message %q", method.FullName(), p, message.FullName())
+			s.pkg.addGenerationError(httpRulePosition(method), err)
+			break
 		}
+		fields[i] = field
+		message = field.Message()
 	}
-	return segs
+	return fields
 }
+
+// leafField returns the field at the end of a field path, or nil if the path does not resolve to a field.
+func leafField(path httprule.FieldPath, message protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	var field protoreflect.FieldDescriptor
+	for _, p := range path {
+		if message == nil {
+			return nil
+		}
+		field = message.Fields().ByName(protoreflect.Name(p))
+		if field == nil {
+			return nil
+		}
+		message = field.Message()
+	}
+	return field
+}
+
+// isSingleSegmentVariable reports whether a path variable matches a single path segment, as in `{name}` or `{name=*}`.
+func isSingleSegmentVariable(v httprule.VariableSegment) bool {
+	return len(v.Segments) == 1 && v.Segments[0].Kind == httprule.SegmentKindMatchSingle
+}
+
+// variablePattern returns a JavaScript regular expression matching the values of a path variable.
+func variablePattern(segments []httprule.Segment) string {
+	parts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		switch seg.Kind {
+		case httprule.SegmentKindLiteral:
+			parts = append(parts, regexpSpecialChars.ReplaceAllString(seg.Literal, `\$0`))
+		case httprule.SegmentKindMatchSingle:
+			parts = append(parts, `[^\/]+`)
+		case httprule.SegmentKindMatchMultiple:
+			parts = append(parts, `.*`)
+		}
+	}
+	return strings.Join(parts, `\/`)
+}
+
+var regexpSpecialChars = regexp.MustCompile(`[.*+?^${}()|[\]\\/]`)
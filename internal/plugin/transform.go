@@ -0,0 +1,240 @@
+package plugin
+
+import (
+	"github.com/evad1n/protoc-gen-typescript-http/internal/codegen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageTransform is a conversion of the values of some fields of a message variant.
+// It is generated as one function per message, which copies the message, converts the values of
+// the matching fields and calls the functions of the messages it references that need converting.
+type messageTransform struct {
+	// The prefix of the generated functions, which is followed by the name of the message variant
+	prefix string
+	// The variant of the messages that are converted
+	variant messageVariant
+	// The documentation of the generated functions
+	doc []string
+	// converts reports whether the values of a field are converted.
+	// Map fields are represented by the field of their values.
+	converts func(field protoreflect.FieldDescriptor) bool
+	// convert returns an expression converting a single value of a field
+	convert func(p *packageGenerator, field protoreflect.FieldDescriptor, value string) string
+	// fills reports whether a field is set by the transform when it is not set in the message
+	fills func(field protoreflect.FieldDescriptor) bool
+	// fill returns an expression for the value of a field that is not set
+	fill func(p *packageGenerator, field protoreflect.FieldDescriptor) string
+	// The types of the input and output of the generated functions, which default to the
+	// type of the message variant
+	inputType, outputType string
+}
+
+// transforms returns the transforms enabled by the options.
+func (g *generator) transforms() []messageTransform {
+	var transforms []messageTransform
+	if g.options.numericEnums {
+		transforms = append(transforms, normalizeEnumsTransform)
+	}
+	if g.options.withDefaults {
+		transforms = append(transforms, withDefaultsTransform)
+	}
+	if g.options.usesCodec() {
+		transforms = append(transforms, decodeTransform(g.options), encodeTransform(g.options))
+	}
+	return transforms
+}
+
+// normalizeEnumsTransform converts numeric enum values in responses to their names.
+// Responses are typed with enum names, so the input of the generated functions is the response
+// as received, in which enums may be numbers.
+var normalizeEnumsTransform = messageTransform{
+	prefix:  "normalize",
+	variant: responseVariant,
+	doc: []string{
+		"Converts numeric enum values in a response to their names, recursively.",
+		"Numbers that are not known to the generated enums are kept unchanged.",
+	},
+	converts: isEnumField,
+	convert: func(p *packageGenerator, field protoreflect.FieldDescriptor, value string) string {
+		enum := field.Enum()
+		name := p.valueName(enum.ParentFile().Package(), enumNormalizerName(descriptorTypeName(enum)))
+		return name + "(" + value + ")"
+	},
+	inputType: "any",
+}
+
+// withDefaultsTransform sets fields with explicit default values in responses, which are omitted
+// from JSON when they are not set.
+var withDefaultsTransform = messageTransform{
+	prefix:  "withDefaults",
+	variant: responseVariant,
+	doc: []string{
+		"Sets the fields of a response that are not set to their explicit default values, recursively.",
+	},
+	fills: func(field protoreflect.FieldDescriptor) bool {
+		return field.HasDefault()
+	},
+	fill: func(p *packageGenerator, field protoreflect.FieldDescriptor) string {
+		return p.defaultValue(field)
+	},
+}
+
+func (t messageTransform) functionName(typeName string) string {
+	return t.prefix + typeName
+}
+
+// applies reports whether the transform converts any values of a message variant, directly or
+// through the messages it references, considering only the fields generated for each variant.
+func (t messageTransform) applies(p *packageGenerator, message protoreflect.MessageDescriptor, variant messageVariant) bool {
+	type messageVariantKey struct {
+		name    protoreflect.FullName
+		variant messageVariant
+	}
+	visited := make(map[messageVariantKey]bool)
+	var applies func(message protoreflect.MessageDescriptor, variant messageVariant) bool
+	applies = func(message protoreflect.MessageDescriptor, variant messageVariant) bool {
+		key := messageVariantKey{name: message.FullName(), variant: variant}
+		if IsWellKnownType(message) || visited[key] {
+			return false
+		}
+		visited[key] = true
+		for i := 0; i < message.Fields().Len(); i++ {
+			field := message.Fields().Get(i)
+			if !isFieldGenerated(field, variant) {
+				continue
+			}
+			if (t.converts != nil && t.converts(field)) || (t.fills != nil && t.fills(field)) {
+				return true
+			}
+			if field.Message() != nil && applies(field.Message(), p.referencedVariant(field.Message(), t.variant)) {
+				return true
+			}
+		}
+		return false
+	}
+	return applies(message, variant)
+}
+
+// variants returns the variants of a message that the transform is generated for: the variant of the
+// transform when it is used directly, such as the output of a method, and the variant that is
+// referenced from fields of messages of that variant.
+func (t messageTransform) variants(p *packageGenerator, message protoreflect.MessageDescriptor) []messageVariant {
+	variants := []messageVariant{t.variant}
+	if referenced := p.referencedVariant(message, t.variant); referenced != t.variant {
+		variants = append(variants, referenced)
+	}
+	return variants
+}
+
+// generate generates the function of the transform for a message variant.
+func (t messageTransform) generate(f *codegen.File, p *packageGenerator, message protoreflect.MessageDescriptor, variant messageVariant) {
+	typeName := p.typeName(message, variant)
+	f.Write("/**")
+	for _, line := range t.doc {
+		f.Write(" * ", line)
+	}
+	f.Write(" */")
+	input, output := typeName, typeName
+	if t.inputType != "" {
+		input = t.inputType
+	}
+	if t.outputType != "" {
+		output = t.outputType
+	}
+	if input == "any" {
+		f.Write("export function ", t.functionName(typeName), "(message: any): ", output, " { // eslint-disable-line @typescript-eslint/no-explicit-any")
+	} else {
+		f.Write("export function ", t.functionName(typeName), "(message: ", input, "): ", output, " {")
+	}
+	f.Write(indentBy(1), "const result: Record<string, unknown> = { ...message };")
+	rangeFields(message, func(field protoreflect.FieldDescriptor) {
+		if !isFieldGenerated(field, variant) {
+			return
+		}
+		convert := t.fieldConversion(p, field)
+		fill := t.fills != nil && t.fills(field)
+		if convert == nil && !fill {
+			return
+		}
+		name := p.options.fieldName(field)
+		if convert == nil {
+			f.Write(indentBy(1), "if (message.", name, " === undefined || message.", name, " === null) {")
+			f.Write(indentBy(2), "result.", name, " = ", t.fill(p, field), ";")
+			f.Write(indentBy(1), "}")
+			return
+		}
+		f.Write(indentBy(1), "if (message.", name, " !== undefined && message.", name, " !== null) {")
+		f.Write(indentBy(2), "result.", name, " = ", convert("message."+name), ";")
+		if fill {
+			f.Write(indentBy(1), "} else {")
+			f.Write(indentBy(2), "result.", name, " = ", t.fill(p, field), ";")
+		}
+		f.Write(indentBy(1), "}")
+	})
+	if output == "unknown" {
+		f.Write(indentBy(1), "return result;")
+	} else {
+		f.Write(indentBy(1), "return result as ", output, ";")
+	}
+	f.Write("}")
+	f.Write()
+}
+
+// fieldConversion returns a function returning an expression converting the value of a field,
+// including all values of repeated and map fields, or nil if the field is not converted.
+func (t messageTransform) fieldConversion(p *packageGenerator, field protoreflect.FieldDescriptor) func(value string) string {
+	value := field
+	if field.IsMap() {
+		value = field.MapValue()
+	}
+	convert := t.valueConversion(p, value)
+	switch {
+	case convert == nil:
+		return nil
+	case field.IsMap():
+		return func(value string) string {
+			return "Object.fromEntries(Object.entries(" + value + ").map(([key, value]) => [key, " + convert("value") + "]))"
+		}
+	case field.IsList():
+		return func(value string) string {
+			return value + ".map((value) => " + convert("value") + ")"
+		}
+	default:
+		return convert
+	}
+}
+
+// messageConversion returns the name of the function of the transform for a message variant,
+// or an empty string if the transform does not apply to the message variant.
+func (t messageTransform) messageConversion(p *packageGenerator, message protoreflect.MessageDescriptor, variant messageVariant) string {
+	if !t.applies(p, message, variant) {
+		return ""
+	}
+	typeName := suffixName(descriptorTypeName(message), variant.suffix())
+	return p.valueName(message.ParentFile().Package(), t.functionName(typeName))
+}
+
+// valueConversion returns a function returning an expression converting a single value of a field,
+// or nil if the values of the field are not converted.
+func (t messageTransform) valueConversion(p *packageGenerator, field protoreflect.FieldDescriptor) func(value string) string {
+	if t.converts != nil && t.converts(field) {
+		return func(value string) string {
+			return t.convert(p, field, value)
+		}
+	}
+	if field.Message() == nil {
+		return nil
+	}
+	message := field.Message()
+	name := t.messageConversion(p, message, p.referencedVariant(message, t.variant))
+	if name == "" {
+		return nil
+	}
+	return func(value string) string {
+		return name + "(" + value + ")"
+	}
+}
+
+func isEnumField(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.EnumKind && !IsWellKnownType(field.Enum())
+}
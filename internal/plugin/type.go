@@ -1,6 +1,12 @@
 package plugin
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
@@ -12,50 +18,76 @@ type Type struct {
 	IsList     bool
 	IsMap      bool
 	Underlying *Type
+	// The type of the keys of a map, which are always strings in JSON
+	Key *Type
+	// Whether a map is written as a Record instead of an index signature
+	IsRecord bool
 }
 
 func (t Type) Reference() string {
 	switch {
 	case t.IsMap:
-		return "{ [key: string]: " + t.Underlying.Reference() + " }"
+		key := t.Key.Reference()
+		// Index signatures only accept string and template literal types,
+		// so a union of literals is written as a mapped type
+		exhaustive := strings.Contains(key, "|")
+		switch {
+		case t.IsRecord && exhaustive:
+			return "Partial<Record<" + key + ", " + t.Underlying.Reference() + ">>"
+		case t.IsRecord:
+			return "Record<" + key + ", " + t.Underlying.Reference() + ">"
+		case exhaustive:
+			return "{ [key in " + key + "]?: " + t.Underlying.Reference() + " }"
+		default:
+			return "{ [key: " + key + "]: " + t.Underlying.Reference() + " }"
+		}
 	case t.IsList:
+		if strings.Contains(t.Underlying.Reference(), "|") {
+			return "(" + t.Underlying.Reference() + ")[]"
+		}
 		return t.Underlying.Reference() + "[]"
 	default:
 		return t.Name
 	}
 }
 
-func typeFromField(pkg protoreflect.FullName, field protoreflect.FieldDescriptor) Type {
+// typeFromField returns the type of a field in a variant of its message.
+func (p *packageGenerator) typeFromField(field protoreflect.FieldDescriptor, variant messageVariant) Type {
 	switch {
 	case field.IsMap():
-		underlying := namedTypeFromField(pkg, field.MapValue())
+		underlying := p.namedTypeFromField(field.MapValue(), variant)
+		key := mapKeyType(field.MapKey())
 		return Type{
 			IsMap:      true,
 			Underlying: &underlying,
+			Key:        &key,
+			IsRecord:   p.options.mapType == mapTypeRecord,
 		}
 	case field.IsList():
-		underlying := namedTypeFromField(pkg, field)
+		underlying := p.namedTypeFromField(field, variant)
 		return Type{
 			IsList:     true,
 			Underlying: &underlying,
 		}
 	default:
-		return namedTypeFromField(pkg, field)
+		return p.namedTypeFromField(field, variant)
 	}
 }
 
-func namedTypeFromField(pkg protoreflect.FullName, field protoreflect.FieldDescriptor) Type {
-
-	// Check if jstype is set to JS_STRING
-	opts, ok := field.Options().(*descriptorpb.FieldOptions)
-	var jstype descriptorpb.FieldOptions_JSType
-	if ok && opts != nil && opts.Jstype != nil {
-		jstype = opts.GetJstype()
-		if jstype == descriptorpb.FieldOptions_JS_STRING {
-			return Type{IsNamed: true, Name: "string"}
-		}
+// mapKeyType returns the type of the keys of a map field in JSON, where keys are always strings.
+// Map keys cannot be enums or messages in protobuf.
+func mapKeyType(key protoreflect.FieldDescriptor) Type {
+	switch key.Kind() {
+	case protoreflect.BoolKind:
+		return Type{IsNamed: true, Name: `"true" | "false"`}
+	case protoreflect.StringKind:
+		return Type{IsNamed: true, Name: "string"}
+	default:
+		return Type{IsNamed: true, Name: "`${number}`"}
 	}
+}
 
+func (p *packageGenerator) namedTypeFromField(field protoreflect.FieldDescriptor, variant messageVariant) Type {
 	switch field.Kind() {
 	case protoreflect.StringKind, protoreflect.BytesKind:
 		return Type{IsNamed: true, Name: "string"}
@@ -63,34 +95,130 @@ func namedTypeFromField(pkg protoreflect.FullName, field protoreflect.FieldDescr
 		return Type{IsNamed: true, Name: "boolean"}
 	case
 		protoreflect.Int32Kind,
-		protoreflect.Int64Kind,
 		protoreflect.Uint32Kind,
-		protoreflect.Uint64Kind,
 		protoreflect.DoubleKind,
 		protoreflect.Fixed32Kind,
-		protoreflect.Fixed64Kind,
 		protoreflect.Sfixed32Kind,
-		protoreflect.Sfixed64Kind,
 		protoreflect.Sint32Kind,
-		protoreflect.Sint64Kind,
 		protoreflect.FloatKind:
 		return Type{IsNamed: true, Name: "number"}
-	case protoreflect.MessageKind:
-		return typeFromMessage(pkg, field.Message())
+	case
+		protoreflect.Int64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Sint64Kind:
+		return Type{IsNamed: true, Name: string(p.options.int64Type(field))}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Groups are encoded as messages in JSON
+		return p.typeFromMessage(field.Message(), p.referencedVariant(field.Message(), variant))
 	case protoreflect.EnumKind:
 		desc := field.Enum()
 		if wkt, ok := WellKnownType(field.Enum()); ok {
+			p.registerWellKnownType(wkt)
 			return Type{IsNamed: true, Name: wkt.Name()}
 		}
-		return Type{IsNamed: true, Name: scopedDescriptorTypeName(pkg, desc)}
+		return Type{IsNamed: true, Name: p.typeName(desc, defaultVariant)}
 	default:
 		return Type{IsNamed: true, Name: "unknown"}
 	}
 }
 
-func typeFromMessage(pkg protoreflect.FullName, message protoreflect.MessageDescriptor) Type {
+// typeFromMessage returns the type of a message variant.
+func (p *packageGenerator) typeFromMessage(message protoreflect.MessageDescriptor, variant messageVariant) Type {
 	if wkt, ok := WellKnownType(message); ok {
+		p.registerWellKnownType(wkt)
 		return Type{IsNamed: true, Name: wkt.Name()}
 	}
-	return Type{IsNamed: true, Name: scopedDescriptorTypeName(pkg, message)}
+	return Type{IsNamed: true, Name: p.typeName(message, variant)}
+}
+
+// int64Type returns the TypeScript type for a 64-bit integer field.
+// An explicit jstype option on the field takes precedence over the int64 option.
+func (o generatorOptions) int64Type(field protoreflect.FieldDescriptor) int64Mapping {
+	if opts, ok := field.Options().(*descriptorpb.FieldOptions); ok && opts != nil && opts.Jstype != nil {
+		switch opts.GetJstype() {
+		case descriptorpb.FieldOptions_JS_STRING:
+			return int64AsString
+		case descriptorpb.FieldOptions_JS_NUMBER:
+			return int64AsNumber
+		}
+	}
+	return o.int64
+}
+
+// isInt64Field reports whether a field holds 64-bit integers.
+func isInt64Field(field protoreflect.FieldDescriptor) bool {
+	switch field.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind,
+		protoreflect.Sfixed64Kind, protoreflect.Sint64Kind:
+		return true
+	}
+	return false
+}
+
+// defaultValue returns the explicit default value of a field as a TypeScript expression.
+func (p *packageGenerator) defaultValue(field protoreflect.FieldDescriptor) string {
+	return defaultLiteral(field, p.options.int64Type(field))
+}
+
+// defaultLiteral returns the explicit default value of a field as a TypeScript literal,
+// with 64-bit integers written as the given type.
+func defaultLiteral(field protoreflect.FieldDescriptor, int64 int64Mapping) string {
+	value := field.Default()
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		return strconv.Quote(string(field.DefaultEnumValue().Name()))
+	case protoreflect.StringKind:
+		return stringLiteral(value.String())
+	case protoreflect.BytesKind:
+		return strconv.Quote(base64.StdEncoding.EncodeToString(value.Bytes()))
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := value.Float()
+		switch {
+		case math.IsInf(f, 1):
+			return "Infinity"
+		case math.IsInf(f, -1):
+			return "-Infinity"
+		case math.IsNaN(f):
+			return "NaN"
+		case field.Kind() == protoreflect.FloatKind:
+			return strconv.FormatFloat(f, 'g', -1, 32)
+		default:
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconv.FormatInt(value.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconv.FormatUint(value.Uint(), 10)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var s string
+		if field.Kind() == protoreflect.Uint64Kind || field.Kind() == protoreflect.Fixed64Kind {
+			s = strconv.FormatUint(value.Uint(), 10)
+		} else {
+			s = strconv.FormatInt(value.Int(), 10)
+		}
+		switch int64 {
+		case int64AsString:
+			return strconv.Quote(s)
+		case int64AsBigint:
+			return s + "n"
+		default:
+			return s
+		}
+	default:
+		return "undefined"
+	}
+}
+
+// stringLiteral returns a string as a JavaScript string literal.
+func stringLiteral(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
 }
@@ -0,0 +1,195 @@
+package plugin
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageVariant is one of the types that can be generated for a message.
+type messageVariant int
+
+const (
+	// defaultVariant is the type of a message with all of its fields, which every message declares.
+	// Fields reference it for messages that do not differ between requests and responses.
+	defaultVariant messageVariant = iota
+	// requestVariant is the type of a message when sent in a request.
+	requestVariant
+	// responseVariant is the type of a message when received in a response.
+	responseVariant
+)
+
+func (v messageVariant) suffix() string {
+	switch v {
+	case requestVariant:
+		return REQUEST_SUFFIX
+	case responseVariant:
+		return RESPONSE_SUFFIX
+	default:
+		return ""
+	}
+}
+
+// messageUsage records which variants of a message are declared by its package.
+type messageUsage struct {
+	usedAsDefault  bool
+	usedInRequest  bool
+	usedInResponse bool
+}
+
+func (u messageUsage) has(variant messageVariant) bool {
+	switch variant {
+	case requestVariant:
+		return u.usedInRequest
+	case responseVariant:
+		return u.usedInResponse
+	default:
+		return u.usedAsDefault
+	}
+}
+
+func (u *messageUsage) add(variant messageVariant) {
+	switch variant {
+	case requestVariant:
+		u.usedInRequest = true
+	case responseVariant:
+		u.usedInResponse = true
+	default:
+		u.usedAsDefault = true
+	}
+}
+
+// registerUsage determines which variants are declared for the messages of all packages.
+// The variants of a message only depend on the message and the services of its own package, so that
+// other packages can import them regardless of which packages are generated in the same invocation.
+func (g *generator) registerUsage(packages []*packageGenerator) {
+	for _, p := range packages {
+		// Every message declares its default type, and messages that differ between requests and
+		// responses declare both other variants, which fields of other messages may reference.
+		for _, name := range sortedKeys(p.messageRegistry) {
+			usage := messageUsage{usedAsDefault: true}
+			if g.messageRequiresDiscrimination(p.messageRegistry[name]) {
+				usage.usedInRequest = true
+				usage.usedInResponse = true
+			}
+			g.usage[name] = usage
+		}
+		// Services determine what messages of their package are used for the request/response
+		for _, service := range sortedDescriptors(p.serviceRegistry) {
+			rangeMethods(service.Methods(), func(method protoreflect.MethodDescriptor) {
+				if !supportedMethod(method) {
+					return
+				}
+				if usage, ok := g.usage[method.Input().FullName()]; ok && p.declares(method.Input()) {
+					usage.add(requestVariant)
+					g.usage[method.Input().FullName()] = usage
+				}
+				if usage, ok := g.usage[method.Output().FullName()]; ok && p.declares(method.Output()) {
+					usage.add(responseVariant)
+					g.usage[method.Output().FullName()] = usage
+				}
+			})
+		}
+	}
+	// Log all messages that are used in requests or responses
+	if g.options.verbose {
+		log("Messages used in requests:")
+		for _, name := range sortedKeys(g.usage) {
+			if g.usage[name].usedInRequest {
+				log(" -", name)
+			}
+		}
+		log("Messages used in responses:")
+		for _, name := range sortedKeys(g.usage) {
+			if g.usage[name].usedInResponse {
+				log(" -", name)
+			}
+		}
+	}
+}
+
+// declares reports whether a message is declared by the package.
+func (p *packageGenerator) declares(message protoreflect.MessageDescriptor) bool {
+	return message.ParentFile().Package() == p.name
+}
+
+// methodVariant returns the variant of a message that is referenced as the input or output of a
+// method of the package. Messages of other packages only declare the variants that do not depend
+// on how their services use them.
+func (p *packageGenerator) methodVariant(message protoreflect.MessageDescriptor, variant messageVariant) messageVariant {
+	if p.declares(message) {
+		return variant
+	}
+	return p.referencedVariant(message, variant)
+}
+
+// referencedVariant returns the variant of a message that is referenced from a field of a message variant.
+// Messages that do not differ between requests and responses are always referenced by their default type.
+func (g *generator) referencedVariant(message protoreflect.MessageDescriptor, variant messageVariant) messageVariant {
+	if variant == defaultVariant || !g.messageRequiresDiscrimination(message) {
+		return defaultVariant
+	}
+	return variant
+}
+
+// messageRequiresDiscrimination reports whether a message has different types in requests and responses.
+func (g *generator) messageRequiresDiscrimination(message protoreflect.MessageDescriptor) bool {
+	return getMessageRequiresDiscrimination(message, 0, make(map[protoreflect.FullName]bool))
+}
+
+// convertedVariant is a message variant converted by the transforms of requests or responses,
+// which are identified by the variant of the transform.
+type convertedVariant struct {
+	name      protoreflect.FullName
+	variant   messageVariant
+	transform messageVariant
+}
+
+// registerConversions determines the message variants that transforms are generated for, which are
+// the variants sent or received by methods, the variants that other packages may reference and the
+// variants that the fields of those reference. Messages that are only used by the methods of their
+// package are referenced by their request or response variant, so their default variant is not converted.
+func (g *generator) registerConversions(packages []*packageGenerator) {
+	var visit func(message protoreflect.MessageDescriptor, variant, transform messageVariant)
+	visit = func(message protoreflect.MessageDescriptor, variant, transform messageVariant) {
+		key := convertedVariant{name: message.FullName(), variant: variant, transform: transform}
+		if IsWellKnownType(message) || g.conversions[key] {
+			return
+		}
+		g.conversions[key] = true
+		rangeFields(message, func(field protoreflect.FieldDescriptor) {
+			if field.IsMap() {
+				field = field.MapValue()
+			}
+			if field.Message() != nil && isFieldGenerated(field, variant) {
+				visit(field.Message(), g.referencedVariant(field.Message(), transform), transform)
+			}
+		})
+	}
+	for _, p := range packages {
+		methodMessages := make(map[protoreflect.FullName]bool)
+		for _, service := range sortedDescriptors(p.serviceRegistry) {
+			rangeMethods(service.Methods(), func(method protoreflect.MethodDescriptor) {
+				if !supportedMethod(method) {
+					return
+				}
+				methodMessages[method.Input().FullName()] = true
+				methodMessages[method.Output().FullName()] = true
+				visit(method.Input(), p.methodVariant(method.Input(), requestVariant), requestVariant)
+				visit(method.Output(), p.methodVariant(method.Output(), responseVariant), responseVariant)
+			})
+		}
+		for _, name := range sortedKeys(p.messageRegistry) {
+			if methodMessages[name] {
+				continue
+			}
+			message := p.messageRegistry[name]
+			for _, transform := range []messageVariant{requestVariant, responseVariant} {
+				visit(message, g.referencedVariant(message, transform), transform)
+			}
+		}
+	}
+}
+
+// converts reports whether the transforms of requests or responses are generated for a message variant.
+func (g *generator) converts(message protoreflect.MessageDescriptor, variant, transform messageVariant) bool {
+	return g.conversions[convertedVariant{name: message.FullName(), variant: variant, transform: transform}]
+}
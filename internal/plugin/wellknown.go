@@ -36,6 +36,10 @@ const (
 	WellKnownValue     WellKnown = "google.protobuf.Value"
 	WellKnownNullValue WellKnown = "google.protobuf.NullValue"
 	WellKnownListValue WellKnown = "google.protobuf.ListValue"
+
+	// wellKnownJSONValue is not a protobuf type, but the recursive JSON value type that
+	// Struct, Value and ListValue are declared with.
+	wellKnownJSONValue WellKnown = "google.protobuf.JsonValue"
 )
 
 func IsWellKnownType(desc protoreflect.Descriptor) bool {
@@ -58,7 +62,7 @@ func (wkt WellKnown) Name() string {
 	return "wellKnown" + strings.TrimPrefix(string(wkt), wellKnownPrefix)
 }
 
-func (wkt WellKnown) TypeDeclaration() string {
+func (wkt WellKnown) TypeDeclaration(opts generatorOptions) string {
 	var w writer
 	switch wkt {
 	case WellKnownAny:
@@ -79,8 +83,21 @@ func (wkt WellKnown) TypeDeclaration() string {
 		w.Write(" * depending on required precision, followed by the suffix \"s\".")
 		w.Write(" * Accepted are any fractional digits (also none) as long as they fit")
 		w.Write(" * into nano-seconds precision and the suffix \"s\" is required.")
-		w.Write(" */")
-		w.Write("type ", wkt.Name(), " = string;")
+		switch opts.duration {
+		case durationAsMillis:
+			w.Write(" *")
+			w.Write(" * Generated clients convert durations to and from milliseconds.")
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = number;")
+		case durationAsObject:
+			w.Write(" *")
+			w.Write(" * Generated clients convert durations to and from seconds and nanoseconds.")
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = { seconds: number; nanos: number };")
+		default:
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = string;")
+		}
 	case WellKnownEmpty:
 		w.Write("/**")
 		w.Write(" * An empty JSON object")
@@ -91,8 +108,21 @@ func (wkt WellKnown) TypeDeclaration() string {
 		w.Write(" * Encoded using RFC 3339, where generated output will always be Z-normalized")
 		w.Write(" * and uses 0, 3, 6 or 9 fractional digits.")
 		w.Write(" * Offsets other than \"Z\" are also accepted.")
-		w.Write(" */")
-		w.Write("type ", wkt.Name(), " = string;")
+		switch opts.timestamp {
+		case timestampAsDate:
+			w.Write(" *")
+			w.Write(" * Generated clients convert timestamps to and from Date, with millisecond precision.")
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = Date;")
+		case timestampAsTemporal:
+			w.Write(" *")
+			w.Write(" * Generated clients convert timestamps to and from Temporal.Instant.")
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = Temporal.Instant;")
+		default:
+			w.Write(" */")
+			w.Write("type ", wkt.Name(), " = string;")
+		}
 	case WellKnownFieldMask:
 		w.Write("/**")
 		w.Write(" * In JSON, a field mask is encoded as a single string where paths are")
@@ -125,26 +155,40 @@ func (wkt WellKnown) TypeDeclaration() string {
 		w.Write("type ", wkt.Name(), " = string;")
 	case WellKnownFloatValue,
 		WellKnownDoubleValue,
-		WellKnownInt64Value,
 		WellKnownInt32Value,
-		WellKnownUInt64Value,
 		WellKnownUInt32Value:
 		w.Write("type ", wkt.Name(), " = number | null;")
+	case WellKnownInt64Value, WellKnownUInt64Value:
+		w.Write("type ", wkt.Name(), " = ", string(opts.int64), " | null;")
 	case WellKnownBytesValue, WellKnownStringValue:
 		w.Write("type ", wkt.Name(), " = string | null;")
 	case WellKnownBoolValue:
 		w.Write("type ", wkt.Name(), " = boolean | null;")
-	case WellKnownStruct:
+	case wellKnownJSONValue:
 		w.Write("/**")
 		w.Write(" * Any JSON value.")
 		w.Write(" */")
-		w.Write("type ", wkt.Name(), " = Record<string, unknown>;")
+		w.Write("type ", wkt.Name(), " =")
+		w.Write("  | null")
+		w.Write("  | boolean")
+		w.Write("  | number")
+		w.Write("  | string")
+		w.Write("  | ", wkt.Name(), "[]")
+		w.Write("  | { [key: string]: ", wkt.Name(), " };")
+	case WellKnownStruct:
+		w.Write("/**")
+		w.Write(" * A JSON object.")
+		w.Write(" */")
+		w.Write("type ", wkt.Name(), " = { [key: string]: ", wellKnownJSONValue.Name(), " };")
 	case WellKnownValue:
-		w.Write("type ", wkt.Name(), " = unknown;")
+		w.Write("type ", wkt.Name(), " = ", wellKnownJSONValue.Name(), ";")
 	case WellKnownNullValue:
-		w.Write("type ", wkt.Name(), " = null;")
+		w.Write("/**")
+		w.Write(" * Encoded as null, while parsers also accept the name of the enum value.")
+		w.Write(" */")
+		w.Write("type ", wkt.Name(), " = \"NULL_VALUE\" | null;")
 	case WellKnownListValue:
-		w.Write("type ", wkt.Name(), " = ", WellKnownValue.Name(), "[];")
+		w.Write("type ", wkt.Name(), " = ", wellKnownJSONValue.Name(), "[];")
 	default:
 		w.Write("/**")
 		w.Write(" * No mapping for this well known type is generated, yet.")